@@ -12,6 +12,7 @@ import (
 	"github.com/rvkinc/uasocial/internal/storage"
 
 	"github.com/rvkinc/uasocial/config"
+	"github.com/rvkinc/uasocial/internal/api"
 	"github.com/rvkinc/uasocial/internal/bot"
 	"go.uber.org/zap"
 )
@@ -51,7 +52,24 @@ func main() {
 		log.Fatalln("migrate storage:", err)
 	}
 
-	b, err := bot.New(ctx, cfg.BotConfig, lg, service.NewService(st))
+	if cfg.ServiceConfig == nil || cfg.ServiceConfig.LinkSecret == "" {
+		log.Fatalln("service.link_secret is required to sign deep links")
+	}
+
+	svc := service.NewService(cfg.ServiceConfig, st)
+
+	if cfg.APIConfig != nil {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := api.New(cfg.APIConfig, lg, svc).Run(ctx)
+			if err != nil {
+				lg.Error("run api", zap.Error(err))
+			}
+		}()
+	}
+
+	b, err := bot.New(ctx, cfg.BotConfig, lg, svc)
 	if err != nil {
 		log.Fatalln("run bot:", err)
 	}
@@ -2,7 +2,9 @@ package config
 
 import (
 	"fmt"
+	"github.com/rvkinc/uasocial/internal/api"
 	"github.com/rvkinc/uasocial/internal/bot"
+	"github.com/rvkinc/uasocial/internal/service"
 	"github.com/rvkinc/uasocial/internal/storage"
 
 	"gopkg.in/yaml.v3"
@@ -11,7 +13,9 @@ import (
 // Config defines service configuration
 type Config struct {
 	BotConfig     *bot.Config     `yaml:"bot"`
+	ServiceConfig *service.Config `yaml:"service"`
 	StorageConfig *storage.Config `yaml:"storage"`
+	APIConfig     *api.Config     `yaml:"api"` // the API is off when not configured
 }
 
 // NewConfig reads config from file
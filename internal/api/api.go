@@ -0,0 +1,124 @@
+// Package api is an authenticated HTTP JSON API over the service layer for coordinator dashboards
+// and a public read-only feed of active helps for people without Telegram.
+package api
+
+import (
+	"context"
+	_ "embed"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/rvkinc/uasocial/internal/service"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultPageLimit = 20
+	maxPageLimit     = 100
+
+	shutdownTimeout = 5 * time.Second
+)
+
+//go:embed openapi.yaml
+var openapi []byte
+
+type Config struct {
+	Addr   string   `yaml:"addr"`
+	Tokens []string `yaml:"tokens"` // bearer tokens of API clients
+
+	// PublicURL is the address the public feed is served at, feed links are made from the request host when empty
+	PublicURL string `yaml:"public_url"`
+}
+
+type Server struct {
+	config  *Config
+	L       *zap.Logger
+	Service *service.Service
+}
+
+func New(config *Config, l *zap.Logger, s *service.Service) *Server {
+	return &Server{config: config, L: l, Service: s}
+}
+
+// Run serves the API until the context is done.
+func (s *Server) Run(ctx context.Context) error {
+	srv := &http.Server{
+		Addr:         s.config.Addr,
+		Handler:      s.handler(),
+		ReadTimeout:  10 * time.Second,
+		WriteTimeout: 30 * time.Second,
+	}
+
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		_ = srv.Shutdown(shutdownCtx)
+	}()
+
+	err := srv.ListenAndServe()
+	if errors.Is(err, http.ErrServerClosed) {
+		return nil
+	}
+	return err
+}
+
+func (s *Server) handler() http.Handler {
+	rt := &router{L: s.L}
+
+	rt.handle(http.MethodGet, "/stats", s.handleStats)
+
+	rt.handle(http.MethodGet, "/helps", s.handleHelps)
+	rt.handle(http.MethodGet, "/helps/search", s.handleSearchHelps)
+	rt.handle(http.MethodGet, "/helps/{id}", s.handleHelp)
+	rt.handle(http.MethodDelete, "/helps/{id}", s.handleDeleteHelp)
+
+	rt.handle(http.MethodGet, "/moderation/helps", s.handlePendingHelps)
+	rt.handle(http.MethodPost, "/moderation/helps/{id}/approve", s.handleApproveHelp)
+	rt.handle(http.MethodPost, "/moderation/helps/{id}/reject", s.handleRejectHelp)
+
+	rt.handle(http.MethodGet, "/categories", s.handleCategories)
+	rt.handle(http.MethodPost, "/categories", s.handleNewCategory)
+	rt.handle(http.MethodPatch, "/categories/{id}", s.handleUpdateCategory)
+	rt.handle(http.MethodPut, "/categories/{id}/parent", s.handleCategoryParent)
+	rt.handle(http.MethodPost, "/categories/{id}/merge", s.handleMergeCategory)
+
+	rt.handle(http.MethodGet, "/users", s.handleUsers)
+	rt.handle(http.MethodGet, "/users/{id}", s.handleUser)
+	rt.handle(http.MethodGet, "/users/{id}/helps", s.handleUserHelps)
+	rt.handle(http.MethodGet, "/users/{id}/subscriptions", s.handleUserSubscriptions)
+	rt.handle(http.MethodPut, "/users/{id}/tier", s.handleUserTier)
+
+	// the public feed is read-only and needs no token
+	public := &router{L: s.L}
+	public.handle(http.MethodGet, "/feed", s.handleFeedHTML)
+	public.handle(http.MethodGet, "/feed/{id}", s.handleFeedHelpHTML)
+	public.handle(http.MethodGet, "/feed.json", s.handleFeedJSON)
+	public.handle(http.MethodGet, "/feed.rss", s.handleFeedRSS)
+	public.handle(http.MethodGet, "/feed.atom", s.handleFeedAtom)
+
+	mux := http.NewServeMux()
+	for _, p := range []string{"/feed", "/feed/", "/feed.json", "/feed.rss", "/feed.atom"} {
+		mux.Handle(p, public)
+	}
+	mux.HandleFunc("/openapi.yaml", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/yaml")
+		_, _ = w.Write(openapi)
+	})
+	mux.Handle("/", s.authorize(rt))
+	return mux
+}
+
+type stats struct {
+	ActiveHelps         int `json:"active_helps"`
+	ActiveSubscriptions int `json:"active_subscriptions"`
+}
+
+func (s *Server) handleStats(w http.ResponseWriter, r *http.Request, _ []string) error {
+	st, err := s.Service.GetActivityStats(r.Context())
+	if err != nil {
+		return err
+	}
+	return writeJSON(w, http.StatusOK, stats{ActiveHelps: st.ActiveHelpsCount, ActiveSubscriptions: st.ActiveSubsCount})
+}
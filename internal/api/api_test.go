@@ -0,0 +1,145 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rvkinc/uasocial/internal/service"
+	"github.com/rvkinc/uasocial/internal/storage"
+	"go.uber.org/zap"
+)
+
+const testToken = "secret"
+
+// apiStorage serves users sorted by sort key and knows no helps.
+type apiStorage struct {
+	storage.Interface
+	users []*storage.User
+}
+
+func (s *apiStorage) SelectUsers(_ context.Context, p storage.Page) ([]*storage.User, error) {
+	var users []*storage.User
+	for _, u := range s.users {
+		if p.AfterKey != nil && (u.SortKey < *p.AfterKey || u.SortKey == *p.AfterKey && u.ID.String() <= p.AfterID.String()) {
+			continue
+		}
+		if len(users) == p.Limit {
+			break
+		}
+		users = append(users, u)
+	}
+	return users, nil
+}
+
+func (s *apiStorage) SelectActivityStats(context.Context) (*storage.ActivityStats, error) {
+	return &storage.ActivityStats{}, nil
+}
+
+func (s *apiStorage) DeleteHelp(context.Context, uuid.UUID) error {
+	return storage.ErrNotFound
+}
+
+func newTestServer(st storage.Interface) http.Handler {
+	s := New(&Config{Tokens: []string{testToken}}, zap.NewNop(), service.NewService(nil, st))
+	return s.handler()
+}
+
+func serve(h http.Handler, method, target, token, body string) *httptest.ResponseRecorder {
+	r := httptest.NewRequest(method, target, strings.NewReader(body))
+	if token != "" {
+		r.Header.Set("Authorization", "Bearer "+token)
+	}
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	return w
+}
+
+func TestHandler(t *testing.T) {
+	h := newTestServer(&apiStorage{})
+
+	tests := []struct {
+		name   string
+		method string
+		target string
+		token  string
+		body   string
+		want   int
+	}{
+		{name: "no token", method: http.MethodGet, target: "/stats", want: http.StatusUnauthorized},
+		{name: "wrong token", method: http.MethodGet, target: "/stats", token: "guess", want: http.StatusUnauthorized},
+		{name: "token", method: http.MethodGet, target: "/stats", token: testToken, want: http.StatusOK},
+		{name: "unknown route", method: http.MethodGet, target: "/nope", token: testToken, want: http.StatusNotFound},
+		{name: "wrong method", method: http.MethodPost, target: "/stats", token: testToken, want: http.StatusMethodNotAllowed},
+		{name: "delete missing help", method: http.MethodDelete, target: "/helps/" + uuid.NewString(), token: testToken, want: http.StatusNotFound},
+		{name: "malformed id", method: http.MethodDelete, target: "/helps/42", token: testToken, want: http.StatusBadRequest},
+		{name: "malformed body", method: http.MethodPut, target: "/users/" + uuid.NewString() + "/tier", token: testToken, body: `{"tier":`, want: http.StatusBadRequest},
+		{name: "unknown body field", method: http.MethodPut, target: "/users/" + uuid.NewString() + "/tier", token: testToken, body: `{"level": "PRO"}`, want: http.StatusBadRequest},
+		{name: "malformed cursor", method: http.MethodGet, target: "/users?cursor=nope", token: testToken, want: http.StatusBadRequest},
+		{name: "limit out of range", method: http.MethodGet, target: "/users?limit=1000", token: testToken, want: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := serve(h, tt.method, tt.target, tt.token, tt.body)
+			if w.Code != tt.want {
+				t.Errorf("%s %s = %d, want %d: %s", tt.method, tt.target, w.Code, tt.want, w.Body)
+			}
+		})
+	}
+}
+
+func TestUsersCursor(t *testing.T) {
+	st := new(apiStorage)
+	for i := 0; i < 5; i++ {
+		st.users = append(st.users, &storage.User{ID: uuid.New(), SortKey: float64(i / 2), CreatedAt: time.Now()})
+	}
+
+	// users sharing a sort key are ordered by id like in storage, names follow the order
+	sort.Slice(st.users, func(i, j int) bool {
+		a, b := st.users[i], st.users[j]
+		return a.SortKey < b.SortKey || a.SortKey == b.SortKey && a.ID.String() < b.ID.String()
+	})
+	for i, u := range st.users {
+		u.Name = string(rune('a' + i))
+	}
+	h := newTestServer(st)
+
+	var (
+		names  []string
+		target = "/users?limit=2"
+	)
+	for pages := 0; target != ""; pages++ {
+		if pages > len(st.users) {
+			t.Fatal("cursor doesn't advance")
+		}
+
+		w := serve(h, http.MethodGet, target, testToken, "")
+		if w.Code != http.StatusOK {
+			t.Fatalf("GET %s = %d: %s", target, w.Code, w.Body)
+		}
+
+		var page userPage
+		if err := json.NewDecoder(w.Body).Decode(&page); err != nil {
+			t.Fatal(err)
+		}
+		for _, u := range page.Users {
+			names = append(names, u.Name)
+		}
+
+		target = ""
+		if page.Next != "" {
+			target = "/users?limit=2&cursor=" + page.Next
+		}
+	}
+
+	if got := strings.Join(names, ""); got != "abcde" {
+		t.Errorf("paged users = %q, want %q", got, "abcde")
+	}
+}
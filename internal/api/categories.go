@@ -0,0 +1,162 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/rvkinc/uasocial/internal/service"
+)
+
+type (
+	category struct {
+		ID       uuid.UUID  `json:"id"`
+		NameUA   string     `json:"name_ua"`
+		NameRU   string     `json:"name_ru"`
+		NameEN   string     `json:"name_en"`
+		ParentID *uuid.UUID `json:"parent_id"`
+		Position int        `json:"position"`
+		Hidden   bool       `json:"hidden"`
+	}
+
+	categoryNames struct {
+		NameUA string `json:"name_ua"`
+		NameRU string `json:"name_ru"`
+		NameEN string `json:"name_en"`
+	}
+
+	// categoryUpdate changes the given fields only, names are changed all together.
+	categoryUpdate struct {
+		NameUA   *string `json:"name_ua"`
+		NameRU   *string `json:"name_ru"`
+		NameEN   *string `json:"name_en"`
+		Hidden   *bool   `json:"hidden"`
+		Position *int    `json:"position"`
+	}
+
+	categoryParent struct {
+		ParentID *uuid.UUID `json:"parent_id"` // null makes the category top level
+	}
+
+	categoryMerge struct {
+		Into uuid.UUID `json:"into"`
+	}
+
+	created struct {
+		ID uuid.UUID `json:"id"`
+	}
+)
+
+func (s *Server) handleCategories(w http.ResponseWriter, r *http.Request, _ []string) error {
+	cs, err := s.Service.GetCategories(r.Context())
+	if err != nil {
+		return err
+	}
+
+	categories := make([]category, 0, len(cs))
+	for _, c := range cs {
+		categories = append(categories, category{
+			ID:       c.ID,
+			NameUA:   c.NameUA,
+			NameRU:   c.NameRU,
+			NameEN:   c.NameEN,
+			ParentID: c.ParentID,
+			Position: c.Position,
+			Hidden:   c.Hidden,
+		})
+	}
+	return writeJSON(w, http.StatusOK, categories)
+}
+
+func (s *Server) handleNewCategory(w http.ResponseWriter, r *http.Request, _ []string) error {
+	var req categoryNames
+	if err := readJSON(r, &req); err != nil {
+		return err
+	}
+
+	id, err := s.Service.NewCategory(r.Context(), req.NameUA, req.NameRU, req.NameEN)
+	if err != nil {
+		return err
+	}
+	return writeJSON(w, http.StatusCreated, created{ID: id})
+}
+
+func (s *Server) handleUpdateCategory(w http.ResponseWriter, r *http.Request, params []string) error {
+	id, err := parseID(params[0])
+	if err != nil {
+		return err
+	}
+
+	var req categoryUpdate
+	if err = readJSON(r, &req); err != nil {
+		return err
+	}
+
+	if req.NameUA != nil || req.NameRU != nil || req.NameEN != nil {
+		if req.NameUA == nil || req.NameRU == nil || req.NameEN == nil {
+			return service.ErrInvalidInput
+		}
+
+		err = s.Service.RenameCategory(r.Context(), id, *req.NameUA, *req.NameRU, *req.NameEN)
+		if err != nil {
+			return err
+		}
+	}
+
+	if req.Hidden != nil {
+		err = s.Service.HideCategory(r.Context(), id, *req.Hidden)
+		if err != nil {
+			return err
+		}
+	}
+
+	if req.Position != nil {
+		err = s.Service.MoveCategory(r.Context(), id, *req.Position)
+		if err != nil {
+			return err
+		}
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
+
+func (s *Server) handleCategoryParent(w http.ResponseWriter, r *http.Request, params []string) error {
+	id, err := parseID(params[0])
+	if err != nil {
+		return err
+	}
+
+	var req categoryParent
+	if err = readJSON(r, &req); err != nil {
+		return err
+	}
+
+	err = s.Service.SetCategoryParent(r.Context(), id, req.ParentID)
+	if err != nil {
+		return err
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
+
+// handleMergeCategory moves helps and subscriptions of the category into another one and deletes it.
+func (s *Server) handleMergeCategory(w http.ResponseWriter, r *http.Request, params []string) error {
+	id, err := parseID(params[0])
+	if err != nil {
+		return err
+	}
+
+	var req categoryMerge
+	if err = readJSON(r, &req); err != nil {
+		return err
+	}
+
+	err = s.Service.MergeCategories(r.Context(), id, req.Into)
+	if err != nil {
+		return err
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
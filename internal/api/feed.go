@@ -0,0 +1,473 @@
+package api
+
+import (
+	"embed"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"html/template"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rvkinc/uasocial/internal/service"
+)
+
+const (
+	feedTitle     = "Допомога від волонтерів"
+	feedPageLimit = 20
+	feedLimit     = 30 // items of RSS and Atom feeds, they have no pagination
+	feedMaxAge    = 60 // seconds public responses are cached for
+)
+
+//go:embed templates/*.html
+var templateFS embed.FS
+
+var templates = template.Must(template.New("").Funcs(template.FuncMap{ // nolint:gochecknoglobals
+	"join":     strings.Join,
+	"datetime": func(t time.Time) string { return t.Format("02.01.2006 15:04") },
+}).ParseFS(templateFS, "templates/*.html"))
+
+type (
+	// feedFilter selects public helps, zero region is the whole country.
+	feedFilter struct {
+		RegionID    int
+		Locality    string
+		CategoryIDs []uuid.UUID
+	}
+
+	feedLinks struct {
+		RSS  string
+		Atom string
+		JSON string
+	}
+
+	categoryOption struct {
+		ID       uuid.UUID
+		Name     string
+		Selected bool
+	}
+
+	feedPage struct {
+		Title      string
+		Locality   string
+		Notice     string
+		Categories []categoryOption
+		Feeds      feedLinks
+		Helps      []service.PublicHelp
+		Next       string
+	}
+
+	singlePage struct {
+		Title   string
+		Feeds   *feedLinks
+		Help    service.PublicHelp
+		BotLink string
+	}
+
+	contact struct {
+		Phone    string `json:"phone,omitempty"`
+		Username string `json:"username,omitempty"`
+	}
+
+	// publicHelp lists its fields explicitly, so fields added to help for coordinators don't leak to the public
+	publicHelp struct {
+		ID           uuid.UUID     `json:"id"`
+		Categories   []string      `json:"categories"`
+		Locality     string        `json:"locality"`
+		Description  string        `json:"description"`
+		CreatedAt    time.Time     `json:"created_at"`
+		Details      helpDetails   `json:"details"`
+		Organisation *organisation `json:"organisation,omitempty"`
+		URL          string        `json:"url"`
+		Contact      *contact      `json:"contact,omitempty"` // set if the volunteer shares contacts
+	}
+
+	publicHelpPage struct {
+		Helps []publicHelp `json:"helps"`
+		Next  string       `json:"next,omitempty"` // cursor of the next page
+	}
+)
+
+// feedFilter reads region and categories, a locality typed by name is resolved to the best match.
+// Unknown locality is reported with ErrNotFound.
+func (s *Server) feedFilter(r *http.Request) (feedFilter, error) {
+	var (
+		q   = r.URL.Query()
+		f   feedFilter
+		err error
+	)
+
+	for _, v := range q["category_id"] {
+		if v == "" { // "all categories" option of the form
+			continue
+		}
+
+		id, err := parseID(v)
+		if err != nil {
+			return feedFilter{}, err
+		}
+		f.CategoryIDs = append(f.CategoryIDs, id)
+	}
+
+	if f.RegionID, err = queryInt(r, "region_id", 0); err != nil {
+		return feedFilter{}, err
+	}
+
+	if f.RegionID == 0 && strings.TrimSpace(q.Get("locality")) != "" {
+		ls, err := s.Service.AutocompleteLocality(r.Context(), strings.TrimSpace(q.Get("locality")))
+		if err != nil {
+			return feedFilter{}, err
+		}
+		if len(ls) == 0 {
+			f.Locality = q.Get("locality")
+			return f, service.ErrNotFound
+		}
+		f.RegionID = ls[0].ID
+	}
+
+	if f.RegionID != 0 {
+		l, err := s.Service.LocalityByID(r.Context(), f.RegionID)
+		if err != nil {
+			return feedFilter{}, err
+		}
+		f.Locality = l.Name
+	}
+	return f, nil
+}
+
+func (f feedFilter) values() url.Values {
+	v := url.Values{}
+	if f.RegionID != 0 {
+		v.Set("region_id", strconv.Itoa(f.RegionID))
+	}
+	for _, id := range f.CategoryIDs {
+		v.Add("category_id", id.String())
+	}
+	return v
+}
+
+func (f feedFilter) links() feedLinks {
+	query := f.values().Encode()
+	return feedLinks{RSS: "/feed.rss?" + query, Atom: "/feed.atom?" + query, JSON: "/feed.json?" + query}
+}
+
+// feedTitle names the region and categories of the feed.
+func (s *Server) feedTitle(r *http.Request, f feedFilter) (string, error) {
+	parts := []string{feedTitle}
+	if f.Locality != "" {
+		parts = append(parts, f.Locality)
+	}
+
+	if len(f.CategoryIDs) > 0 {
+		categories, err := s.Service.GetCategories(r.Context())
+		if err != nil {
+			return "", err
+		}
+
+		var names []string
+		for _, c := range categories {
+			for _, id := range f.CategoryIDs {
+				if c.ID == id {
+					names = append(names, c.NameUA)
+				}
+			}
+		}
+		parts = append(parts, strings.Join(names, ", "))
+	}
+	return strings.Join(parts, " — "), nil
+}
+
+// baseURL is the configured public address or the address the request came to.
+func (s *Server) baseURL(r *http.Request) string {
+	if s.config.PublicURL != "" {
+		return strings.TrimSuffix(s.config.PublicURL, "/")
+	}
+
+	scheme := "http"
+	if r.TLS != nil || r.Header.Get("X-Forwarded-Proto") == "https" {
+		scheme = "https"
+	}
+	return fmt.Sprintf("%s://%s", scheme, r.Host)
+}
+
+func (s *Server) handleFeedHTML(w http.ResponseWriter, r *http.Request, _ []string) error {
+	f, err := s.feedFilter(r)
+	notFound := errors.Is(err, service.ErrNotFound)
+	if err != nil && !notFound {
+		return err
+	}
+
+	title, err := s.feedTitle(r, f)
+	if err != nil {
+		return err
+	}
+
+	page := feedPage{Title: title, Locality: f.Locality, Feeds: f.links()}
+
+	categories, err := s.Service.GetCategories(r.Context())
+	if err != nil {
+		return err
+	}
+	for _, c := range categories {
+		if c.Hidden {
+			continue
+		}
+
+		option := categoryOption{ID: c.ID, Name: c.NameUA}
+		for _, id := range f.CategoryIDs {
+			option.Selected = option.Selected || id == c.ID
+		}
+		page.Categories = append(page.Categories, option)
+	}
+
+	if notFound {
+		page.Notice = "Населений пункт не знайдено"
+		return s.writeHTML(w, http.StatusNotFound, "feed", page)
+	}
+
+	after, _, err := queryPage(r)
+	if err != nil {
+		return err
+	}
+
+	helps, err := s.Service.PublicFeed(r.Context(), f.RegionID, f.CategoryIDs, after, feedPageLimit)
+	if err != nil {
+		return err
+	}
+
+	page.Helps = helps.Helps
+	if helps.Next != nil {
+		v := f.values()
+		v.Set("cursor", helps.Next.String())
+		page.Next = "/feed?" + v.Encode()
+	}
+	return s.writeHTML(w, http.StatusOK, "feed", page)
+}
+
+func (s *Server) handleFeedHelpHTML(w http.ResponseWriter, r *http.Request, params []string) error {
+	id, err := parseID(params[0])
+	if err != nil {
+		return err
+	}
+
+	h, err := s.Service.PublicHelp(r.Context(), id)
+	if err != nil {
+		return err
+	}
+
+	return s.writeHTML(w, http.StatusOK, "single", singlePage{
+		Title:   fmt.Sprintf("%s — %s", feedTitle, h.Locality),
+		Help:    h,
+		BotLink: s.Service.HelpLink(h.ID),
+	})
+}
+
+func (s *Server) handleFeedJSON(w http.ResponseWriter, r *http.Request, _ []string) error {
+	f, err := s.feedFilter(r)
+	if err != nil {
+		return err
+	}
+
+	after, limit, err := queryPage(r)
+	if err != nil {
+		return err
+	}
+
+	p, err := s.Service.PublicFeed(r.Context(), f.RegionID, f.CategoryIDs, after, limit)
+	if err != nil {
+		return err
+	}
+
+	page := publicHelpPage{Helps: make([]publicHelp, 0, len(p.Helps)), Next: nextCursor(p.Next)}
+	for _, h := range p.Helps {
+		page.Helps = append(page.Helps, newPublicHelp(h, s.helpURL(r, h.ID)))
+	}
+
+	w.Header().Set("Cache-Control", fmt.Sprintf("public, max-age=%d", feedMaxAge))
+	return writeJSON(w, http.StatusOK, page)
+}
+
+func newPublicHelp(h service.PublicHelp, link string) publicHelp {
+	full := newHelp(h.UserHelp)
+	ph := publicHelp{
+		ID:           full.ID,
+		Categories:   full.Categories,
+		Locality:     full.Locality,
+		Description:  full.Description,
+		CreatedAt:    full.CreatedAt,
+		Details:      full.Details,
+		Organisation: full.Organisation,
+		URL:          link,
+	}
+	if !h.Contact.Empty() {
+		ph.Contact = &contact{Phone: h.Contact.Phone, Username: h.Contact.Username}
+	}
+	return ph
+}
+
+func (s *Server) helpURL(r *http.Request, id uuid.UUID) string {
+	return fmt.Sprintf("%s/feed/%s", s.baseURL(r), id)
+}
+
+func (s *Server) writeHTML(w http.ResponseWriter, status int, name string, data interface{}) error {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Cache-Control", fmt.Sprintf("public, max-age=%d", feedMaxAge))
+	w.WriteHeader(status)
+	return templates.ExecuteTemplate(w, name, data)
+}
+
+type (
+	rss struct {
+		XMLName xml.Name   `xml:"rss"`
+		Version string     `xml:"version,attr"`
+		Channel rssChannel `xml:"channel"`
+	}
+
+	rssChannel struct {
+		Title       string    `xml:"title"`
+		Link        string    `xml:"link"`
+		Description string    `xml:"description"`
+		Items       []rssItem `xml:"item"`
+	}
+
+	rssItem struct {
+		Title       string `xml:"title"`
+		Link        string `xml:"link"`
+		GUID        string `xml:"guid"`
+		PubDate     string `xml:"pubDate"`
+		Description string `xml:"description"`
+	}
+
+	atomFeed struct {
+		XMLName xml.Name    `xml:"http://www.w3.org/2005/Atom feed"`
+		Title   string      `xml:"title"`
+		ID      string      `xml:"id"`
+		Updated string      `xml:"updated"`
+		Links   []atomLink  `xml:"link"`
+		Entries []atomEntry `xml:"entry"`
+	}
+
+	atomLink struct {
+		Href string `xml:"href,attr"`
+		Rel  string `xml:"rel,attr,omitempty"`
+	}
+
+	atomEntry struct {
+		Title   string   `xml:"title"`
+		ID      string   `xml:"id"`
+		Updated string   `xml:"updated"`
+		Link    atomLink `xml:"link"`
+		Content string   `xml:"content"`
+	}
+)
+
+// feedItem returns title and text of the help for feed readers.
+func feedItem(h service.PublicHelp) (string, string) {
+	title := fmt.Sprintf("%s — %s", strings.Join(h.Categories, ", "), h.Locality)
+
+	var b strings.Builder
+	b.WriteString(h.Description)
+	if h.Contact.Phone != "" {
+		b.WriteString(fmt.Sprintf("\n📞 %s", h.Contact.Phone))
+	}
+	if h.Contact.Username != "" {
+		b.WriteString(fmt.Sprintf("\n✈️ @%s", h.Contact.Username))
+	}
+	return title, b.String()
+}
+
+func (s *Server) handleFeedRSS(w http.ResponseWriter, r *http.Request, _ []string) error {
+	f, title, helps, err := s.feed(r)
+	if err != nil {
+		return err
+	}
+
+	feed := rss{Version: "2.0", Channel: rssChannel{
+		Title:       title,
+		Link:        fmt.Sprintf("%s/feed?%s", s.baseURL(r), f.values().Encode()),
+		Description: title,
+		Items:       make([]rssItem, 0, len(helps)),
+	}}
+	for _, h := range helps {
+		itemTitle, text := feedItem(h)
+		link := s.helpURL(r, h.ID)
+		feed.Channel.Items = append(feed.Channel.Items, rssItem{
+			Title:       itemTitle,
+			Link:        link,
+			GUID:        link,
+			PubDate:     h.CreatedAt.Format(time.RFC1123Z),
+			Description: text,
+		})
+	}
+	return writeXML(w, "application/rss+xml", feed)
+}
+
+func (s *Server) handleFeedAtom(w http.ResponseWriter, r *http.Request, _ []string) error {
+	f, title, helps, err := s.feed(r)
+	if err != nil {
+		return err
+	}
+
+	query := f.values().Encode()
+	feed := atomFeed{
+		Title:   title,
+		ID:      fmt.Sprintf("%s/feed.atom?%s", s.baseURL(r), query),
+		Updated: time.Now().Format(time.RFC3339),
+		Links: []atomLink{
+			{Href: fmt.Sprintf("%s/feed.atom?%s", s.baseURL(r), query), Rel: "self"},
+			{Href: fmt.Sprintf("%s/feed?%s", s.baseURL(r), query)},
+		},
+		Entries: make([]atomEntry, 0, len(helps)),
+	}
+	if len(helps) > 0 {
+		feed.Updated = helps[0].CreatedAt.Format(time.RFC3339)
+	}
+
+	for _, h := range helps {
+		itemTitle, text := feedItem(h)
+		link := s.helpURL(r, h.ID)
+		feed.Entries = append(feed.Entries, atomEntry{
+			Title:   itemTitle,
+			ID:      link,
+			Updated: h.CreatedAt.Format(time.RFC3339),
+			Link:    atomLink{Href: link},
+			Content: text,
+		})
+	}
+	return writeXML(w, "application/atom+xml", feed)
+}
+
+// feed returns the newest helps for RSS and Atom feeds.
+func (s *Server) feed(r *http.Request) (feedFilter, string, []service.PublicHelp, error) {
+	f, err := s.feedFilter(r)
+	if err != nil {
+		return feedFilter{}, "", nil, err
+	}
+
+	title, err := s.feedTitle(r, f)
+	if err != nil {
+		return feedFilter{}, "", nil, err
+	}
+
+	page, err := s.Service.PublicFeed(r.Context(), f.RegionID, f.CategoryIDs, nil, feedLimit)
+	if err != nil {
+		return feedFilter{}, "", nil, err
+	}
+	return f, title, page.Helps, nil
+}
+
+func writeXML(w http.ResponseWriter, contentType string, v interface{}) error {
+	w.Header().Set("Content-Type", contentType+"; charset=utf-8")
+	w.Header().Set("Cache-Control", fmt.Sprintf("public, max-age=%d", feedMaxAge))
+	w.WriteHeader(http.StatusOK)
+
+	_, err := w.Write([]byte(xml.Header))
+	if err != nil {
+		return err
+	}
+	return xml.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,45 @@
+package api
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/rvkinc/uasocial/internal/service"
+)
+
+func TestPublicHelpFields(t *testing.T) {
+	h := service.PublicHelp{
+		UserHelp: service.UserHelp{
+			ID:           uuid.New(),
+			CreatorID:    uuid.New(),
+			HasContact:   true,
+			Pending:      true,
+			Organisation: &service.HelpOrganisation{Name: "Food bank", Verified: true},
+		},
+		Contact: service.Contact{Phone: "+380671234567"},
+	}
+
+	b, err := json.Marshal(newPublicHelp(h, "https://example.com/feed/1"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var fields map[string]json.RawMessage
+	if err = json.Unmarshal(b, &fields); err != nil {
+		t.Fatal(err)
+	}
+
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	want := "categories contact created_at description details id locality organisation url"
+	if got := strings.Join(keys, " "); got != want {
+		t.Errorf("public help fields = %q, want %q", got, want)
+	}
+}
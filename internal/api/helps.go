@@ -0,0 +1,245 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rvkinc/uasocial/internal/service"
+)
+
+type (
+	help struct {
+		ID               uuid.UUID     `json:"id"`
+		CreatorID        uuid.UUID     `json:"creator_id"`
+		Categories       []string      `json:"categories"`
+		Locality         string        `json:"locality"`
+		Description      string        `json:"description"`
+		Headline         string        `json:"headline,omitempty"`
+		CreatedAt        time.Time     `json:"created_at"`
+		AttachmentsCount int           `json:"attachments_count"`
+		HasContact       bool          `json:"has_contact"`
+		Pending          bool          `json:"pending"`
+		Details          helpDetails   `json:"details"`
+		Organisation     *organisation `json:"organisation,omitempty"`
+		Reputation       reputation    `json:"reputation"`
+	}
+
+	helpDetails struct {
+		Capacity      int        `json:"capacity,omitempty"`
+		CapacityUnit  string     `json:"capacity_unit,omitempty"`
+		AvailableFrom *time.Time `json:"available_from,omitempty"`
+		AvailableTo   *time.Time `json:"available_to,omitempty"`
+		Weekdays      []int      `json:"weekdays,omitempty"` // 0 is Sunday
+		HoursFrom     *int       `json:"hours_from,omitempty"`
+		HoursTo       *int       `json:"hours_to,omitempty"`
+		Languages     []string   `json:"languages,omitempty"`
+	}
+
+	organisation struct {
+		Name     string `json:"name"`
+		Verified bool   `json:"verified"`
+	}
+
+	reputation struct {
+		Positive int `json:"positive"`
+		Negative int `json:"negative"`
+	}
+
+	helpPage struct {
+		Helps []help `json:"helps"`
+		Next  string `json:"next,omitempty"` // cursor of the next page
+	}
+
+	pendingHelp struct {
+		Help    help     `json:"help"`
+		Score   int      `json:"score"`
+		Reasons []string `json:"reasons"`
+	}
+)
+
+func newHelp(h service.UserHelp) help {
+	result := help{
+		ID:               h.ID,
+		CreatorID:        h.CreatorID,
+		Categories:       h.Categories,
+		Locality:         h.Locality,
+		Description:      h.Description,
+		Headline:         h.Headline,
+		CreatedAt:        h.CreatedAt,
+		AttachmentsCount: h.AttachmentsCount,
+		HasContact:       h.HasContact,
+		Pending:          h.Pending,
+		Details: helpDetails{
+			Capacity:      h.Details.Capacity,
+			CapacityUnit:  h.Details.CapacityUnit,
+			AvailableFrom: h.Details.AvailableFrom,
+			AvailableTo:   h.Details.AvailableTo,
+			HoursFrom:     h.Details.HoursFrom,
+			HoursTo:       h.Details.HoursTo,
+			Languages:     h.Details.Languages,
+		},
+		Reputation: reputation{Positive: h.Reputation.Positive, Negative: h.Reputation.Negative},
+	}
+
+	for _, d := range h.Details.Weekdays {
+		result.Details.Weekdays = append(result.Details.Weekdays, int(d))
+	}
+
+	if h.Organisation != nil {
+		result.Organisation = &organisation{Name: h.Organisation.Name, Verified: h.Organisation.Verified}
+	}
+	return result
+}
+
+func newHelpPage(p service.HelpPage) helpPage {
+	page := helpPage{Helps: make([]help, 0, len(p.Helps)), Next: nextCursor(p.Next)}
+	for _, h := range p.Helps {
+		page.Helps = append(page.Helps, newHelp(h))
+	}
+	return page
+}
+
+// handleHelps lists helps around the locality, in all categories if none are given.
+func (s *Server) handleHelps(w http.ResponseWriter, r *http.Request, _ []string) error {
+	after, limit, err := queryPage(r)
+	if err != nil {
+		return err
+	}
+
+	q := service.HelpQuery{
+		Sort:     service.HelpSort(r.URL.Query().Get("sort")),
+		Language: r.URL.Query().Get("language"),
+	}
+
+	if q.LocalityID, err = queryInt(r, "locality_id", 0); err != nil {
+		return err
+	}
+	if q.Radius, err = queryInt(r, "radius", 0); err != nil {
+		return err
+	}
+	if q.CategoryIDs, err = queryIDs(r, "category_id"); err != nil {
+		return err
+	}
+	if q.HasPhone, err = queryBool(r, "has_phone"); err != nil {
+		return err
+	}
+	if q.AvailableNow, err = queryBool(r, "available_now"); err != nil {
+		return err
+	}
+	if q.HasCapacity, err = queryBool(r, "has_capacity"); err != nil {
+		return err
+	}
+	if q.VerifiedOnly, err = queryBool(r, "verified_only"); err != nil {
+		return err
+	}
+
+	if q.LocalityID <= 0 || q.Radius < 0 || (q.Sort != "" && !q.Sort.Valid()) {
+		return service.ErrInvalidInput
+	}
+
+	if len(q.CategoryIDs) == 0 {
+		categories, err := s.Service.GetCategories(r.Context())
+		if err != nil {
+			return err
+		}
+		for _, c := range categories {
+			q.CategoryIDs = append(q.CategoryIDs, c.ID)
+		}
+	}
+
+	page, err := s.Service.HelpsByCategoryLocation(r.Context(), q, after, limit)
+	if err != nil {
+		return err
+	}
+	return writeJSON(w, http.StatusOK, newHelpPage(page))
+}
+
+// handleSearchHelps searches helps by description, the most relevant first.
+func (s *Server) handleSearchHelps(w http.ResponseWriter, r *http.Request, _ []string) error {
+	after, limit, err := queryPage(r)
+	if err != nil {
+		return err
+	}
+
+	q := service.TextSearch{Text: r.URL.Query().Get("q")}
+	if q.RegionID, err = queryInt(r, "region_id", 0); err != nil {
+		return err
+	}
+	if q.CategoryIDs, err = queryIDs(r, "category_id"); err != nil {
+		return err
+	}
+
+	page, err := s.Service.SearchHelps(r.Context(), q, after, limit)
+	if err != nil {
+		return err
+	}
+	return writeJSON(w, http.StatusOK, newHelpPage(page))
+}
+
+func (s *Server) handleHelp(w http.ResponseWriter, r *http.Request, params []string) error {
+	id, err := parseID(params[0])
+	if err != nil {
+		return err
+	}
+
+	h, err := s.Service.HelpByID(r.Context(), id)
+	if err != nil {
+		return err
+	}
+	return writeJSON(w, http.StatusOK, newHelp(h))
+}
+
+func (s *Server) handleDeleteHelp(w http.ResponseWriter, r *http.Request, params []string) error {
+	id, err := parseID(params[0])
+	if err != nil {
+		return err
+	}
+
+	err = s.Service.DeleteHelp(r.Context(), id)
+	if err != nil {
+		return err
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
+
+// handlePendingHelps lists the oldest helps held for moderation with their scores.
+func (s *Server) handlePendingHelps(w http.ResponseWriter, r *http.Request, _ []string) error {
+	events, err := s.Service.PendingHelps(r.Context())
+	if err != nil {
+		return err
+	}
+
+	helps := make([]pendingHelp, 0, len(events))
+	for _, e := range events {
+		helps = append(helps, pendingHelp{Help: newHelp(e.Help), Score: e.Score, Reasons: e.Reasons})
+	}
+	return writeJSON(w, http.StatusOK, helps)
+}
+
+func (s *Server) handleApproveHelp(w http.ResponseWriter, r *http.Request, params []string) error {
+	return s.moderateHelp(w, r, params[0], s.Service.ApproveHelp)
+}
+
+func (s *Server) handleRejectHelp(w http.ResponseWriter, r *http.Request, params []string) error {
+	return s.moderateHelp(w, r, params[0], s.Service.RejectHelp)
+}
+
+// moderateHelp responds with not found if the help isn't pending anymore.
+func (s *Server) moderateHelp(w http.ResponseWriter, r *http.Request, param string, moderate func(ctx context.Context, id uuid.UUID) error) error {
+	id, err := parseID(param)
+	if err != nil {
+		return err
+	}
+
+	err = moderate(r.Context(), id)
+	if err != nil {
+		return err
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
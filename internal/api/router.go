@@ -0,0 +1,212 @@
+package api
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/rvkinc/uasocial/internal/service"
+	"go.uber.org/zap"
+)
+
+// handlerFunc gets path parameters in the order of placeholders in the route pattern.
+type handlerFunc func(w http.ResponseWriter, r *http.Request, params []string) error
+
+type route struct {
+	method   string
+	segments []string // "{...}" segments are placeholders
+	handler  handlerFunc
+}
+
+// router matches requests by method and path segments, errors of handlers are written as JSON.
+type router struct {
+	L      *zap.Logger
+	routes []route
+}
+
+func (rt *router) handle(method, pattern string, h handlerFunc) {
+	rt.routes = append(rt.routes, route{method: method, segments: splitPath(pattern), handler: h})
+}
+
+func (rt *router) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var (
+		path    = splitPath(r.URL.Path)
+		matched bool
+	)
+
+	for _, route := range rt.routes {
+		params, ok := route.match(path)
+		if !ok {
+			continue
+		}
+
+		matched = true
+		if route.method != r.Method {
+			continue
+		}
+
+		err := route.handler(w, r, params)
+		if err != nil {
+			rt.writeError(w, r, err)
+		}
+		return
+	}
+
+	if matched {
+		_ = writeJSON(w, http.StatusMethodNotAllowed, errorResponse{Error: "method not allowed"})
+		return
+	}
+	_ = writeJSON(w, http.StatusNotFound, errorResponse{Error: "not found"})
+}
+
+func (r route) match(path []string) ([]string, bool) {
+	if len(path) != len(r.segments) {
+		return nil, false
+	}
+
+	var params []string
+	for i, s := range r.segments {
+		switch {
+		case strings.HasPrefix(s, "{"):
+			params = append(params, path[i])
+		case s != path[i]:
+			return nil, false
+		}
+	}
+	return params, true
+}
+
+func splitPath(p string) []string {
+	return strings.Split(strings.Trim(p, "/"), "/")
+}
+
+// authorize lets through requests with one of the configured bearer tokens.
+func (s *Server) authorize(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		for _, t := range s.config.Tokens {
+			if t != "" && subtle.ConstantTimeCompare([]byte(token), []byte(t)) == 1 {
+				next.ServeHTTP(w, r)
+				return
+			}
+		}
+
+		w.Header().Set("WWW-Authenticate", "Bearer")
+		_ = writeJSON(w, http.StatusUnauthorized, errorResponse{Error: "unauthorized"})
+	})
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+func (rt *router) writeError(w http.ResponseWriter, r *http.Request, err error) {
+	var status int
+	switch {
+	case errors.Is(err, service.ErrInvalidInput):
+		status = http.StatusBadRequest
+	case errors.Is(err, service.ErrNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, service.ErrAlreadyExists):
+		status = http.StatusConflict
+	default:
+		rt.L.Error("handle api request", zap.Error(err), zap.String("method", r.Method), zap.String("path", r.URL.Path))
+		_ = writeJSON(w, http.StatusInternalServerError, errorResponse{Error: "internal error"})
+		return
+	}
+
+	_ = writeJSON(w, status, errorResponse{Error: err.Error()})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	return json.NewEncoder(w).Encode(v)
+}
+
+// readJSON decodes request body, malformed body is invalid input.
+func readJSON(r *http.Request, v interface{}) error {
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		return service.ErrInvalidInput
+	}
+	return nil
+}
+
+func parseID(s string) (uuid.UUID, error) {
+	id, err := uuid.Parse(s)
+	if err != nil {
+		return uuid.UUID{}, service.ErrInvalidInput
+	}
+	return id, nil
+}
+
+// queryInt returns the query parameter or the default if it is absent.
+func queryInt(r *http.Request, name string, def int) (int, error) {
+	v := r.URL.Query().Get(name)
+	if v == "" {
+		return def, nil
+	}
+
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		return 0, service.ErrInvalidInput
+	}
+	return n, nil
+}
+
+func queryBool(r *http.Request, name string) (bool, error) {
+	v := r.URL.Query().Get(name)
+	if v == "" {
+		return false, nil
+	}
+
+	b, err := strconv.ParseBool(v)
+	if err != nil {
+		return false, service.ErrInvalidInput
+	}
+	return b, nil
+}
+
+func queryIDs(r *http.Request, name string) ([]uuid.UUID, error) {
+	var ids []uuid.UUID
+	for _, v := range r.URL.Query()[name] {
+		id, err := parseID(v)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// queryPage returns the cursor and the page size, the first page has no cursor.
+func queryPage(r *http.Request) (*service.Cursor, int, error) {
+	limit, err := queryInt(r, "limit", defaultPageLimit)
+	if err != nil {
+		return nil, 0, err
+	}
+	if limit < 1 || limit > maxPageLimit {
+		return nil, 0, service.ErrInvalidInput
+	}
+
+	cursor := r.URL.Query().Get("cursor")
+	if cursor == "" {
+		return nil, limit, nil
+	}
+
+	after, err := service.ParseCursor(cursor)
+	return after, limit, err
+}
+
+func nextCursor(c *service.Cursor) string {
+	if c == nil {
+		return ""
+	}
+	return c.String()
+}
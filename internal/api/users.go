@@ -0,0 +1,182 @@
+package api
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rvkinc/uasocial/internal/service"
+)
+
+type (
+	user struct {
+		ID        uuid.UUID `json:"id"`
+		TgID      int       `json:"tg_id"`
+		Name      string    `json:"name"`
+		Language  string    `json:"language"`
+		Tier      string    `json:"tier"`
+		CreatedAt time.Time `json:"created_at"`
+	}
+
+	// userDetails is a single user with preferences and counters.
+	userDetails struct {
+		user
+		NotificationMode   string `json:"notification_mode"`
+		SearchRadius       int    `json:"search_radius"`
+		ShareContact       bool   `json:"share_contact"`
+		HelpsCount         int    `json:"helps_count"`
+		SubscriptionsCount int    `json:"subscriptions_count"`
+		Limits             limits `json:"limits"`
+	}
+
+	limits struct {
+		HelpsPerUser         int `json:"helps_per_user"`
+		SubscriptionsPerUser int `json:"subscriptions_per_user"`
+	}
+
+	userPage struct {
+		Users []user `json:"users"`
+		Next  string `json:"next,omitempty"` // cursor of the next page
+	}
+
+	subscription struct {
+		ID         uuid.UUID `json:"id"`
+		Categories []string  `json:"categories"`
+		Locality   string    `json:"locality"`
+		Keywords   string    `json:"keywords,omitempty"`
+		CreatedAt  time.Time `json:"created_at"`
+	}
+
+	userTier struct {
+		Tier string `json:"tier"`
+	}
+)
+
+func newUser(u service.User) user {
+	return user{
+		ID:        u.ID,
+		TgID:      u.TgID,
+		Name:      u.Name,
+		Language:  u.Language,
+		Tier:      u.Tier,
+		CreatedAt: u.CreatedAt,
+	}
+}
+
+func (s *Server) handleUsers(w http.ResponseWriter, r *http.Request, _ []string) error {
+	after, limit, err := queryPage(r)
+	if err != nil {
+		return err
+	}
+
+	p, err := s.Service.Users(r.Context(), after, limit)
+	if err != nil {
+		return err
+	}
+
+	page := userPage{Users: make([]user, 0, len(p.Users)), Next: nextCursor(p.Next)}
+	for _, u := range p.Users {
+		page.Users = append(page.Users, newUser(u))
+	}
+	return writeJSON(w, http.StatusOK, page)
+}
+
+func (s *Server) handleUser(w http.ResponseWriter, r *http.Request, params []string) error {
+	id, err := parseID(params[0])
+	if err != nil {
+		return err
+	}
+
+	u, err := s.Service.UserByID(r.Context(), id)
+	if err != nil {
+		return err
+	}
+
+	helps, err := s.Service.HelpsCountByUser(r.Context(), id)
+	if err != nil {
+		return err
+	}
+
+	subscriptions, err := s.Service.SubscriptionsCountByUser(r.Context(), id)
+	if err != nil {
+		return err
+	}
+
+	l := s.Service.UserLimits(u.Tier)
+	return writeJSON(w, http.StatusOK, userDetails{
+		user:               newUser(u),
+		NotificationMode:   u.Preferences.Mode,
+		SearchRadius:       u.Preferences.SearchRadius,
+		ShareContact:       u.Preferences.ShareContact,
+		HelpsCount:         helps,
+		SubscriptionsCount: subscriptions,
+		Limits:             limits{HelpsPerUser: l.HelpsPerUser, SubscriptionsPerUser: l.SubscriptionsPerUser},
+	})
+}
+
+func (s *Server) handleUserHelps(w http.ResponseWriter, r *http.Request, params []string) error {
+	id, err := parseID(params[0])
+	if err != nil {
+		return err
+	}
+
+	after, limit, err := queryPage(r)
+	if err != nil {
+		return err
+	}
+
+	page, err := s.Service.UserHelps(r.Context(), id, after, limit)
+	if err != nil {
+		return err
+	}
+	return writeJSON(w, http.StatusOK, newHelpPage(page))
+}
+
+func (s *Server) handleUserSubscriptions(w http.ResponseWriter, r *http.Request, params []string) error {
+	id, err := parseID(params[0])
+	if err != nil {
+		return err
+	}
+
+	ss, err := s.Service.UserSubscriptions(r.Context(), id)
+	if err != nil {
+		return err
+	}
+
+	subscriptions := make([]subscription, 0, len(ss))
+	for _, sub := range ss {
+		subscriptions = append(subscriptions, subscription{
+			ID:         sub.ID,
+			Categories: sub.Categories,
+			Locality:   sub.Locality,
+			Keywords:   sub.Keywords,
+			CreatedAt:  sub.CreatedAt,
+		})
+	}
+	return writeJSON(w, http.StatusOK, subscriptions)
+}
+
+func (s *Server) handleUserTier(w http.ResponseWriter, r *http.Request, params []string) error {
+	id, err := parseID(params[0])
+	if err != nil {
+		return err
+	}
+
+	var req userTier
+	if err = readJSON(r, &req); err != nil {
+		return err
+	}
+
+	u, err := s.Service.UserByID(r.Context(), id)
+	if err != nil {
+		return err
+	}
+
+	err = s.Service.SetUserTier(r.Context(), u.TgID, req.Tier)
+	if err != nil {
+		return err
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
@@ -0,0 +1,231 @@
+package bot
+
+import (
+	"errors"
+	"fmt"
+	"html"
+	"strconv"
+	"strings"
+
+	tg "github.com/go-telegram-bot-api/telegram-bot-api"
+	"github.com/google/uuid"
+	"github.com/rvkinc/uasocial/internal/service"
+)
+
+var errInvalidArguments = errors.New("invalid command arguments")
+
+// isUsageError reports whether err is caused by malformed command arguments, e.g. a number out of range.
+func isUsageError(err error) bool {
+	var numErr *strconv.NumError
+	return errors.Is(err, service.ErrInvalidInput) || errors.Is(err, errInvalidArguments) || errors.As(err, &numErr)
+}
+
+// handleCmdAdminCategory handles category management commands, available for admin only.
+func (m *MessageHandler) handleCmdAdminCategory(u *Update) error {
+	var (
+		cmd  = u.Message.Command()
+		args = strings.TrimSpace(u.Message.CommandArguments())
+		err  error
+	)
+
+	switch cmd {
+	case cmdCategories:
+		return m.sendCategoriesList(u)
+	case cmdCategoryAdd: // /category_add UA | RU | EN
+		var names []string
+		names, err = parseCategoryNames(args)
+		if err == nil {
+			_, err = m.Service.NewCategory(u.ctx, names[0], names[1], names[2])
+		}
+	case cmdCategoryRename: // /category_rename <id> UA | RU | EN
+		var (
+			fields = strings.SplitN(args, " ", 2)
+			ids    []uuid.UUID
+			names  []string
+		)
+		if len(fields) != 2 {
+			err = errInvalidArguments
+			break
+		}
+		ids, err = parseUUIDs(fields[:1])
+		if err == nil {
+			names, err = parseCategoryNames(fields[1])
+		}
+		if err == nil {
+			err = m.Service.RenameCategory(u.ctx, ids[0], names[0], names[1], names[2])
+		}
+	case cmdCategoryMove: // /category_move <id> <position>
+		var (
+			fields   = strings.Fields(args)
+			ids      []uuid.UUID
+			position int
+		)
+		if len(fields) != 2 {
+			err = errInvalidArguments
+			break
+		}
+		ids, err = parseUUIDs(fields[:1])
+		if err == nil {
+			position, err = strconv.Atoi(fields[1])
+		}
+		if err == nil {
+			// positions are shown starting from 1
+			err = m.Service.MoveCategory(u.ctx, ids[0], position-1)
+		}
+	case cmdCategoryHide, cmdCategoryShow: // /category_hide <id>
+		var ids []uuid.UUID
+		ids, err = parseUUIDs(strings.Fields(args))
+		if err == nil && len(ids) != 1 {
+			err = errInvalidArguments
+		}
+		if err == nil {
+			err = m.Service.HideCategory(u.ctx, ids[0], cmd == cmdCategoryHide)
+		}
+	case cmdCategoryParent: // /category_parent <id> [parent id]
+		var ids []uuid.UUID
+		ids, err = parseUUIDs(strings.Fields(args))
+		switch {
+		case err != nil:
+		case len(ids) == 1:
+			err = m.Service.SetCategoryParent(u.ctx, ids[0], nil)
+		case len(ids) == 2:
+			err = m.Service.SetCategoryParent(u.ctx, ids[0], &ids[1])
+		default:
+			err = errInvalidArguments
+		}
+	case cmdCategoryMerge: // /category_merge <from id> <to id>
+		var ids []uuid.UUID
+		ids, err = parseUUIDs(strings.Fields(args))
+		if err == nil && len(ids) != 2 {
+			err = errInvalidArguments
+		}
+		if err == nil {
+			err = m.Service.MergeCategories(u.ctx, ids[0], ids[1])
+		}
+	}
+
+	switch {
+	case err == nil:
+		return m.sendCategoriesList(u)
+	case errors.Is(err, service.ErrNotFound):
+		_, err = m.Api.Send(tg.NewMessage(u.chatID(), m.Localize.Translate(adminCategoryNotFoundTr, u.lang())))
+		return err
+	case errors.Is(err, service.ErrAlreadyExists):
+		_, err = m.Api.Send(tg.NewMessage(u.chatID(), m.Localize.Translate(adminCategoryAlreadyExistsTr, u.lang())))
+		return err
+	case isUsageError(err):
+		_, err = m.Api.Send(tg.NewMessage(u.chatID(), m.Localize.Translate(adminCategoryUsageTr, u.lang())))
+		return err
+	default:
+		return err
+	}
+}
+
+func (m *MessageHandler) sendCategoriesList(u *Update) error {
+	categories, err := m.Service.GetCategories(u.ctx)
+	if err != nil {
+		return err
+	}
+
+	var (
+		b     strings.Builder
+		write func(parent *uuid.UUID, depth int)
+	)
+
+	// categories are listed as a tree, numbers are positions used by /category_move
+	write = func(parent *uuid.UUID, depth int) {
+		for i, c := range categories {
+			if (parent == nil) != (c.ParentID == nil) || (parent != nil && *parent != *c.ParentID) {
+				continue
+			}
+
+			b.WriteString(strings.Repeat("    ", depth))
+			b.WriteString(fmt.Sprintf("%d. %s / %s / %s", i+1, html.EscapeString(c.NameUA), html.EscapeString(c.NameRU), html.EscapeString(c.NameEN)))
+			if c.Hidden {
+				b.WriteString(" " + emojiHidden)
+			}
+			b.WriteString(fmt.Sprintf("\n%s<code>%s</code>\n", strings.Repeat("    ", depth), c.ID))
+
+			id := c.ID
+			write(&id, depth+1)
+		}
+	}
+
+	b.WriteString(fmt.Sprintf("%s\n\n", m.Localize.Translate(adminCategoryListHeaderTr, u.lang())))
+	write(nil, 0)
+	b.WriteString(fmt.Sprintf("\n%s", html.EscapeString(m.Localize.Translate(adminCategoryUsageTr, u.lang()))))
+
+	msg := tg.NewMessage(u.chatID(), b.String())
+	msg.ParseMode = "HTML"
+	msg.ReplyMarkup = tg.ReplyKeyboardHide{HideKeyboard: true}
+	_, err = m.Api.Send(msg)
+	return err
+}
+
+// handleCmdAdminTier changes user tier by telegram id: /set_tier <telegram id> <tier>, available for admin only.
+func (m *MessageHandler) handleCmdAdminTier(u *Update) error {
+	var (
+		fields = strings.Fields(u.Message.CommandArguments())
+		tgID   int
+		tier   string
+		err    = errInvalidArguments
+	)
+
+	if len(fields) == 2 {
+		tier = strings.ToUpper(fields[1])
+		tgID, err = strconv.Atoi(fields[0])
+	}
+	if err == nil {
+		err = m.Service.SetUserTier(u.ctx, tgID, tier)
+	}
+
+	switch {
+	case err == nil:
+		limits := m.Service.UserLimits(tier)
+		_, err = m.Api.Send(tg.NewMessage(u.chatID(), fmt.Sprintf(m.Localize.Translate(adminTierUpdatedTr, u.lang()),
+			tgID, strings.ToLower(tier), limits.HelpsPerUser, limits.SubscriptionsPerUser)))
+		return err
+	case errors.Is(err, service.ErrNotFound):
+		_, err = m.Api.Send(tg.NewMessage(u.chatID(), m.Localize.Translate(adminUserNotFoundTr, u.lang())))
+		return err
+	case isUsageError(err):
+		_, err = m.Api.Send(tg.NewMessage(u.chatID(), m.Localize.Translate(adminTierUsageTr, u.lang())))
+		return err
+	default:
+		return err
+	}
+}
+
+// parseCategoryNames parses "UA | RU | EN" category names.
+func parseCategoryNames(s string) ([]string, error) {
+	names := strings.Split(s, "|")
+	if len(names) != 3 {
+		return nil, errInvalidArguments
+	}
+
+	for i := range names {
+		names[i] = strings.TrimSpace(names[i])
+		if names[i] == "" {
+			return nil, errInvalidArguments
+		}
+	}
+
+	return names, nil
+}
+
+func parseUUIDs(ss []string) ([]uuid.UUID, error) {
+	if len(ss) == 0 {
+		return nil, errInvalidArguments
+	}
+
+	ids := make([]uuid.UUID, 0, len(ss))
+	for _, s := range ss {
+		id, err := uuid.Parse(s)
+		if err != nil {
+			return nil, errInvalidArguments
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
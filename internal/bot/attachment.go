@@ -0,0 +1,81 @@
+package bot
+
+import (
+	"encoding/json"
+	"net/url"
+	"strconv"
+
+	tg "github.com/go-telegram-bot-api/telegram-bot-api"
+	"github.com/rvkinc/uasocial/internal/service"
+)
+
+// inputMedia is an item of a media group, the library has no documents in media groups.
+type inputMedia struct {
+	Type  string `json:"type"`
+	Media string `json:"media"`
+}
+
+// messageAttachment returns photo or document of the message, the largest photo size is taken.
+func messageAttachment(msg *tg.Message) (service.Attachment, bool) {
+	switch {
+	case msg.Photo != nil && len(*msg.Photo) > 0:
+		photos := *msg.Photo
+		return service.Attachment{Type: service.AttachmentPhoto, FileID: photos[len(photos)-1].FileID}, true
+	case msg.Document != nil:
+		return service.Attachment{Type: service.AttachmentDocument, FileID: msg.Document.FileID}, true
+	}
+	return service.Attachment{}, false
+}
+
+// sendAttachments sends photos and documents of a help as media groups, photos and documents can't be grouped together.
+func (m *MessageHandler) sendAttachments(chatID int64, attachments []service.Attachment) error {
+	var photos, documents []service.Attachment
+	for _, a := range attachments {
+		if a.Type == service.AttachmentPhoto {
+			photos = append(photos, a)
+		} else {
+			documents = append(documents, a)
+		}
+	}
+
+	for _, group := range [][]service.Attachment{photos, documents} {
+		err := m.sendMediaGroup(chatID, group)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// sendMediaGroup sends a single attachment as is, media group must have at least two items.
+func (m *MessageHandler) sendMediaGroup(chatID int64, attachments []service.Attachment) error {
+	switch len(attachments) {
+	case 0:
+		return nil
+	case 1:
+		var c tg.Chattable = tg.NewDocumentShare(chatID, attachments[0].FileID)
+		if attachments[0].Type == service.AttachmentPhoto {
+			c = tg.NewPhotoShare(chatID, attachments[0].FileID)
+		}
+		_, err := m.Api.Send(c)
+		return err
+	}
+
+	media := make([]inputMedia, 0, len(attachments))
+	for _, a := range attachments {
+		media = append(media, inputMedia{Type: a.Type, Media: a.FileID})
+	}
+
+	data, err := json.Marshal(media)
+	if err != nil {
+		return err
+	}
+
+	v := url.Values{}
+	v.Add("chat_id", strconv.FormatInt(chatID, 10))
+	v.Add("media", string(data))
+
+	// sendMediaGroup responds with an array of messages Api.Send can't decode
+	_, err = m.Api.MakeRequest("sendMediaGroup", v)
+	return err
+}
@@ -0,0 +1,225 @@
+package bot
+
+import (
+	"fmt"
+
+	tg "github.com/go-telegram-bot-api/telegram-bot-api"
+	"github.com/google/uuid"
+	"github.com/rvkinc/uasocial/internal/service"
+)
+
+type pickerAction int
+
+const (
+	pickerUnknown   pickerAction = iota // text does not match any button
+	pickerNavigated                     // moved one level up or down
+	pickerToggled                       // checkbox was checked or unchecked
+)
+
+// categoryPicker renders categories checkbox keyboard one level of hierarchy at a time.
+type categoryPicker struct {
+	categories service.CategoriesTranslated
+	parent     *service.CategoryTranslated // current level, nil for top level categories
+	checked    []service.CategoryTranslated
+
+	backBtn  string
+	allInBtn string // format string for the button to choose the parent category itself
+}
+
+// handleCategoryPickerReply applies reply to the picker and sends the updated keyboard.
+// requestTr is sent when user navigates between category levels.
+func (m *MessageHandler) handleCategoryPickerReply(u *Update, p *categoryPicker, requestTr string) error {
+	action := p.handle(u.Message.Text)
+	if action == pickerUnknown {
+		// garbage value
+		_, err := m.Api.Send(tg.NewMessage(u.chatID(), m.Localize.Translate(errorChooseOptionTr, u.lang())))
+		return err
+	}
+
+	selected := p.selected()
+
+	var txt string
+	switch {
+	case len(selected) != 0:
+		txt = fmt.Sprintf("%s:\n\n", m.Localize.Translate(chosenCategoriesHeaderTr, u.lang()))
+		for _, c := range selected {
+			txt += fmt.Sprintf("%s %s\n", emojiItem, c.Name)
+		}
+		txt += fmt.Sprintf("%s %s", m.Localize.Translate(chosenCategoriesFooterTr, u.lang()), m.Localize.Translate(btnOptionNextTr, u.lang()))
+	case action == pickerNavigated:
+		txt = m.Localize.Translate(requestTr, u.lang())
+	default:
+		txt = m.Localize.Translate(errorChooseOptionTr, u.lang())
+	}
+
+	// show or hide next button
+	nextbtn := ""
+	if len(selected) > 0 {
+		nextbtn = m.Localize.Translate(btnOptionNextTr, u.lang())
+	}
+
+	msg := tg.NewMessage(u.chatID(), txt)
+	msg.ReplyMarkup = tg.ReplyKeyboardMarkup{
+		OneTimeKeyboard: false,
+		ResizeKeyboard:  true,
+		Keyboard:        p.layout(m.Localize.Translate(btnOptionCancelTr, u.lang()), nextbtn),
+	}
+
+	_, err := m.Api.Send(msg)
+	return err
+}
+
+func (m *MessageHandler) newCategoryPicker(lang string) *categoryPicker {
+	return &categoryPicker{
+		categories: m.categories.translated(lang),
+		backBtn:    m.Localize.Translate(btnOptionBackTr, lang),
+		allInBtn:   m.Localize.Translate(btnOptionAllInCategoryTr, lang),
+	}
+}
+
+// check checks categories by their ids, unknown ids are skipped.
+func (p *categoryPicker) check(ids []uuid.UUID) {
+	for i := range ids {
+		if c := p.byID(&ids[i]); c != nil && !p.isChecked(c.ID) {
+			p.toggle(*c)
+		}
+	}
+}
+
+// selected returns checked categories in order of selection.
+func (p *categoryPicker) selected() []service.CategoryTranslated { return p.checked }
+
+func (p *categoryPicker) children(parent *service.CategoryTranslated) []service.CategoryTranslated {
+	children := make([]service.CategoryTranslated, 0)
+	for _, c := range p.categories {
+		switch {
+		case parent == nil && c.ParentID == nil:
+			children = append(children, c)
+		case parent != nil && c.ParentID != nil && *c.ParentID == parent.ID:
+			children = append(children, c)
+		}
+	}
+	return children
+}
+
+func (p *categoryPicker) hasChildren(c service.CategoryTranslated) bool {
+	for _, x := range p.categories {
+		if x.ParentID != nil && *x.ParentID == c.ID {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *categoryPicker) isChecked(cid uuid.UUID) bool {
+	for _, c := range p.checked {
+		if c.ID == cid {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *categoryPicker) toggle(c service.CategoryTranslated) {
+	for i, x := range p.checked {
+		if x.ID == c.ID {
+			p.checked = append(p.checked[:i], p.checked[i+1:]...)
+			return
+		}
+	}
+	p.checked = append(p.checked, c)
+}
+
+func (p *categoryPicker) checkboxText(text string, cid uuid.UUID) string {
+	if p.isChecked(cid) {
+		return emojiCheckbox + " " + text
+	}
+	return text
+}
+
+func (p *categoryPicker) categoryText(c service.CategoryTranslated) string {
+	if p.hasChildren(c) {
+		return emojiFolder + " " + c.Name
+	}
+	return p.checkboxText(c.Name, c.ID)
+}
+
+func (p *categoryPicker) allInText() string {
+	return p.checkboxText(fmt.Sprintf(p.allInBtn, p.parent.Name), p.parent.ID)
+}
+
+// layout returns keyboard of the current level with cancel and optional next button at the bottom.
+func (p *categoryPicker) layout(cancelbtn, nextbtn string) [][]tg.KeyboardButton {
+	children := p.children(p.parent)
+	layout := make([][]tg.KeyboardButton, 0, len(children)/2+3)
+
+	if p.parent != nil {
+		layout = append(layout, []tg.KeyboardButton{{Text: p.allInText()}})
+	}
+
+	for _, c := range children {
+		if len(layout) == 0 || len(layout[len(layout)-1]) == 2 || (p.parent != nil && len(layout) == 1) {
+			layout = append(layout, []tg.KeyboardButton{{Text: p.categoryText(c)}})
+			continue
+		}
+
+		layout[len(layout)-1] = append(layout[len(layout)-1], tg.KeyboardButton{Text: p.categoryText(c)})
+	}
+
+	if p.parent != nil {
+		layout = append(layout, []tg.KeyboardButton{{Text: p.backBtn}})
+	}
+
+	if nextbtn != "" {
+		return append(layout, []tg.KeyboardButton{
+			{Text: cancelbtn},
+			{Text: nextbtn},
+		})
+	}
+
+	return append(layout, []tg.KeyboardButton{{Text: cancelbtn}})
+}
+
+// handle applies pressed button text to the picker.
+func (p *categoryPicker) handle(text string) pickerAction {
+	if p.parent != nil && text == p.backBtn {
+		p.parent = p.byID(p.parent.ParentID)
+		return pickerNavigated
+	}
+
+	if p.parent != nil && text == p.allInText() {
+		p.toggle(*p.parent)
+		return pickerToggled
+	}
+
+	for _, c := range p.children(p.parent) {
+		if text != p.categoryText(c) {
+			continue
+		}
+
+		if p.hasChildren(c) {
+			c := c
+			p.parent = &c
+			return pickerNavigated
+		}
+
+		p.toggle(c)
+		return pickerToggled
+	}
+
+	return pickerUnknown
+}
+
+func (p *categoryPicker) byID(cid *uuid.UUID) *service.CategoryTranslated {
+	if cid == nil {
+		return nil
+	}
+
+	for i := range p.categories {
+		if p.categories[i].ID == *cid {
+			return &p.categories[i]
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,71 @@
+package bot
+
+import (
+	"context"
+
+	tg "github.com/go-telegram-bot-api/telegram-bot-api"
+	"github.com/rvkinc/uasocial/internal/service"
+	"go.uber.org/zap"
+)
+
+// channelLang is the language helps are posted to channels in.
+const channelLang = UALang
+
+func (m *MessageHandler) listenHelpEvents(ctx context.Context) {
+	for {
+		select {
+		case e := <-m.Service.HelpEvents():
+			m.handleHelpEvent(ctx, e)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (m *MessageHandler) handleHelpEvent(ctx context.Context, e service.HelpEvent) {
+	switch e.Kind {
+	case service.HelpPublished:
+		for _, chatID := range e.ChatIDs {
+			msg := tg.NewMessage(chatID, m.helpText(e.Help, channelLang))
+			msg.ReplyMarkup = m.channelPostKeyboard(e)
+			sent, err := m.Api.Send(msg)
+			if err != nil {
+				m.L.Error("post help to channel", zap.Error(err), zap.Int64("chat_id", chatID))
+				continue
+			}
+
+			err = m.Service.SaveChannelPost(ctx, e.Help.ID, service.ChannelPost{ChatID: chatID, MessageID: sent.MessageID})
+			if err != nil {
+				m.L.Error("save channel post", zap.Error(err))
+			}
+		}
+
+	case service.HelpEdited:
+		for _, p := range e.Posts {
+			keyboard := m.channelPostKeyboard(e)
+			edit := tg.NewEditMessageText(p.ChatID, p.MessageID, m.helpText(e.Help, channelLang))
+			edit.ReplyMarkup = &keyboard
+			_, err := m.Api.Send(edit)
+			if err != nil {
+				m.L.Error("edit channel post", zap.Error(err), zap.Int64("chat_id", p.ChatID))
+			}
+		}
+
+	case service.HelpRemoved:
+		for _, p := range e.Posts {
+			_, err := m.Api.DeleteMessage(tg.NewDeleteMessage(p.ChatID, p.MessageID))
+			if err != nil {
+				m.L.Error("delete channel post", zap.Error(err), zap.Int64("chat_id", p.ChatID))
+			}
+		}
+	}
+}
+
+// channelPostKeyboard links the help in the bot, contact is revealed in channel if volunteer shares it.
+func (m *MessageHandler) channelPostKeyboard(e service.HelpEvent) tg.InlineKeyboardMarkup {
+	row := tg.NewInlineKeyboardRow(tg.NewInlineKeyboardButtonURL(m.Localize.Translate(btnOptionOpenInBotTr, channelLang), e.Link))
+	if e.ShareContact {
+		row = append(row, m.showContactButton(e.Help.ID, channelLang))
+	}
+	return tg.NewInlineKeyboardMarkup(row)
+}
@@ -0,0 +1,136 @@
+package bot
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	tg "github.com/go-telegram-bot-api/telegram-bot-api"
+	"github.com/google/uuid"
+	"github.com/rvkinc/uasocial/internal/service"
+)
+
+const (
+	emojiPhone    = "📞"
+	emojiUsername = "✈️"
+)
+
+// contactText renders contact with a line per field.
+func contactText(c service.Contact) string {
+	var b strings.Builder
+	if c.Phone != "" {
+		b.WriteString(fmt.Sprintf("%s %s\n", emojiPhone, c.Phone))
+	}
+	if c.Username != "" {
+		b.WriteString(fmt.Sprintf("%s @%s\n", emojiUsername, c.Username))
+	}
+	return b.String()
+}
+
+func (m *MessageHandler) showContactButton(hid uuid.UUID, lang string) tg.InlineKeyboardButton {
+	return tg.NewInlineKeyboardButtonData(m.Localize.Translate(btnOptionShowContactTr, lang), fmt.Sprintf("%s|%s", cqShowContact, hid))
+}
+
+func (m *MessageHandler) contactKeyboard(hid uuid.UUID, lang string) tg.InlineKeyboardMarkup {
+	return tg.NewInlineKeyboardMarkup(tg.NewInlineKeyboardRow(m.showContactButton(hid, lang)))
+}
+
+// handleShowContactCallback sends contact of the help to private chat,
+// in groups and channels the contact is shown in an alert to the user who asked.
+func (m *MessageHandler) handleShowContactCallback(u *Update, value string) error {
+	hid, err := uuid.Parse(value)
+	if err != nil {
+		return fmt.Errorf("parse help id: %w", err)
+	}
+
+	uid, err := u.userUUID()
+	if err != nil {
+		return err
+	}
+
+	var txt string
+	c, err := m.Service.HelpContact(u.ctx, hid, uid, !u.isPrivate())
+	switch {
+	case errors.Is(err, service.ErrNotFound):
+		txt = m.Localize.Translate(errorContactUnavailableTr, u.lang())
+	case err != nil:
+		return err
+	default:
+		txt = contactText(c)
+	}
+
+	if !u.isPrivate() {
+		_, err = m.Api.AnswerCallbackQuery(tg.NewCallbackWithAlert(u.CallbackQuery.ID, txt))
+		return err
+	}
+
+	_, err = m.Api.AnswerCallbackQuery(tg.NewCallback(u.CallbackQuery.ID, ""))
+	if err != nil {
+		return err
+	}
+
+	_, err = m.Api.Send(tg.NewMessage(u.chatID(), txt))
+	return err
+}
+
+func (m *MessageHandler) sendVolunteerContactRequest(u *Update, tr string) error {
+	d := m.dialogs.get(u.dialogKey())
+
+	txt := m.Localize.Translate(tr, u.lang())
+	if !d.volunteer.contact.Empty() {
+		txt = fmt.Sprintf("%s\n\n%s", contactText(d.volunteer.contact), txt)
+	}
+
+	keyboard := [][]tg.KeyboardButton{{tg.NewKeyboardButtonContact(m.Localize.Translate(btnOptionSharePhoneTr, u.lang()))}}
+	if username := u.tgUser().UserName; username != "" {
+		keyboard = append(keyboard, []tg.KeyboardButton{{Text: fmt.Sprintf(m.Localize.Translate(btnOptionUseUsernameTr, u.lang()), username)}})
+	}
+	keyboard = append(keyboard, []tg.KeyboardButton{
+		{Text: m.Localize.Translate(btnOptionCancelTr, u.lang())},
+		{Text: m.Localize.Translate(btnOptionNextTr, u.lang())},
+	})
+
+	msg := tg.NewMessage(u.chatID(), txt)
+	msg.ReplyMarkup = tg.ReplyKeyboardMarkup{Keyboard: keyboard, ResizeKeyboard: true}
+	_, err := m.Api.Send(msg)
+	if err != nil {
+		return err
+	}
+
+	d.next = m.handleVolunteerContactReply
+	return nil
+}
+
+// handleVolunteerContactReply takes shared telegram contact, typed ukrainian phone number or telegram username.
+func (m *MessageHandler) handleVolunteerContactReply(u *Update) error {
+	d := m.dialogs.get(u.dialogKey())
+
+	switch {
+	case u.Message.Contact != nil:
+		phone, err := service.NormalizeSharedPhone(u.Message.Contact.PhoneNumber)
+		if err != nil {
+			return m.sendPhoneFormatError(u)
+		}
+		d.volunteer.contact.Phone = phone
+
+	case u.Message.Text == m.Localize.Translate(btnOptionNextTr, u.lang()):
+		return m.sendVolunteerDetailsMenu(u)
+
+	case u.tgUser().UserName != "" && u.Message.Text == fmt.Sprintf(m.Localize.Translate(btnOptionUseUsernameTr, u.lang()), u.tgUser().UserName):
+		d.volunteer.contact.Username = u.tgUser().UserName
+
+	default:
+		phone, err := service.NormalizeUAPhone(u.Message.Text)
+		if err != nil {
+			return m.sendPhoneFormatError(u)
+		}
+		d.volunteer.contact.Phone = phone
+	}
+
+	return m.sendVolunteerContactRequest(u, volunteerContactAddedTr)
+}
+
+func (m *MessageHandler) sendPhoneFormatError(u *Update) error {
+	_, err := m.Api.Send(tg.NewMessage(u.chatID(), m.Localize.Translate(errorPhoneFormatTr, u.lang())))
+	return err
+}
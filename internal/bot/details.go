@@ -0,0 +1,481 @@
+package bot
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	tg "github.com/go-telegram-bot-api/telegram-bot-api"
+	"github.com/rvkinc/uasocial/internal/service"
+)
+
+const (
+	emojiCapacity     = "👥"
+	emojiAvailability = "📅"
+	emojiLanguages    = "🗣"
+
+	availabilityDateLayout = "02.01"
+)
+
+// languageNames are native names of languages volunteers may speak.
+var languageNames = map[string]string{ // nolint:gochecknoglobals
+	"UA": "Українська",
+	"RU": "Русский",
+	"EN": "English",
+	"PL": "Polski",
+	"DE": "Deutsch",
+}
+
+var capacityUnits = []struct{ unit, btn, tr string }{ // nolint:gochecknoglobals
+	{service.CapacitySeats, btnOptionCapacitySeatsTr, capacitySeatsTr},
+	{service.CapacityBeds, btnOptionCapacityBedsTr, capacityBedsTr},
+	{service.CapacityKg, btnOptionCapacityKgTr, capacityKgTr},
+}
+
+// weekdaysOrder lists weekdays starting from Monday.
+var weekdaysOrder = []time.Weekday{ // nolint:gochecknoglobals
+	time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday, time.Saturday, time.Sunday,
+}
+
+var (
+	dateRangeRe  = regexp.MustCompile(`^(\d{1,2})\.(\d{1,2})(?:\.(\d{4}))?\s*-\s*(\d{1,2})\.(\d{1,2})(?:\.(\d{4}))?$`) // nolint:gochecknoglobals
+	hourRangeRe  = regexp.MustCompile(`^(\d{1,2})(?::00)?\s*-\s*(\d{1,2})(?::00)?$`)                                   // nolint:gochecknoglobals
+	dashReplacer = strings.NewReplacer("–", "-", "—", "-")                                                             // nolint:gochecknoglobals
+)
+
+// detailsText renders structured fields of a help, empty fields are omitted.
+func (m *MessageHandler) detailsText(d service.HelpDetails, lang string) string {
+	var b strings.Builder
+
+	for _, cu := range capacityUnits {
+		if d.Capacity > 0 && cu.unit == d.CapacityUnit {
+			b.WriteString(fmt.Sprintf("%s %s\n", emojiCapacity, fmt.Sprintf(m.Localize.Translate(cu.tr, lang), d.Capacity)))
+		}
+	}
+
+	if availability := m.availabilityText(d, lang); availability != "" {
+		b.WriteString(fmt.Sprintf("%s %s\n", emojiAvailability, availability))
+	}
+
+	if len(d.Languages) > 0 {
+		names := make([]string, 0, len(d.Languages))
+		for _, l := range d.Languages {
+			names = append(names, languageNames[l])
+		}
+		b.WriteString(fmt.Sprintf("%s %s\n", emojiLanguages, strings.Join(names, ", ")))
+	}
+
+	return b.String()
+}
+
+// availabilityText renders availability as "10.05–31.05, пн–пт, 09:00–18:00".
+func (m *MessageHandler) availabilityText(d service.HelpDetails, lang string) string {
+	parts := make([]string, 0, 3)
+
+	switch {
+	case d.AvailableFrom != nil && d.AvailableTo != nil:
+		parts = append(parts, fmt.Sprintf("%s–%s", d.AvailableFrom.Format(availabilityDateLayout), d.AvailableTo.Format(availabilityDateLayout)))
+	case d.AvailableFrom != nil:
+		parts = append(parts, fmt.Sprintf(m.Localize.Translate(availableFromTr, lang), d.AvailableFrom.Format(availabilityDateLayout)))
+	case d.AvailableTo != nil:
+		parts = append(parts, fmt.Sprintf(m.Localize.Translate(availableToTr, lang), d.AvailableTo.Format(availabilityDateLayout)))
+	}
+
+	if len(d.Weekdays) > 0 {
+		parts = append(parts, m.weekdaysText(d.Weekdays, lang))
+	}
+
+	if d.HoursFrom != nil && d.HoursTo != nil {
+		parts = append(parts, formatQuietHours(*d.HoursFrom, *d.HoursTo))
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+// weekdaysText renders runs of three and more days as ranges, e.g. "пн–пт, нд".
+func (m *MessageHandler) weekdaysText(weekdays []time.Weekday, lang string) string {
+	set := make(map[time.Weekday]bool, len(weekdays))
+	for _, wd := range weekdays {
+		set[wd] = true
+	}
+
+	var (
+		parts = make([]string, 0)
+		run   = make([]time.Weekday, 0, len(weekdaysOrder))
+	)
+
+	flush := func() {
+		switch {
+		case len(run) >= 3:
+			parts = append(parts, fmt.Sprintf("%s–%s", m.Localize.WeekDayShort(run[0], lang), m.Localize.WeekDayShort(run[len(run)-1], lang)))
+		default:
+			for _, wd := range run {
+				parts = append(parts, m.Localize.WeekDayShort(wd, lang))
+			}
+		}
+		run = run[:0]
+	}
+
+	for _, wd := range weekdaysOrder {
+		if set[wd] {
+			run = append(run, wd)
+			continue
+		}
+		flush()
+	}
+	flush()
+
+	return strings.Join(parts, ", ")
+}
+
+// parseAvailability parses "10.05-31.05, пн-пт, 9-18" into availability fields of d, every part is optional.
+// Dates without a year are in the current year, the range end is moved to the next year when needed.
+func (m *MessageHandler) parseAvailability(s string, now time.Time) (service.HelpDetails, bool) {
+	var d service.HelpDetails
+
+	for _, part := range strings.Split(dashReplacer.Replace(strings.ToLower(s)), ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		if match := dateRangeRe.FindStringSubmatch(part); match != nil {
+			from, ok := parseDate(match[1], match[2], match[3], now.Year())
+			if !ok {
+				return d, false
+			}
+
+			to, ok := parseDate(match[4], match[5], match[6], from.Year())
+			if !ok {
+				return d, false
+			}
+
+			if to.Before(from) && match[6] == "" {
+				to = to.AddDate(1, 0, 0)
+			}
+
+			d.AvailableFrom, d.AvailableTo = &from, &to
+			continue
+		}
+
+		if match := hourRangeRe.FindStringSubmatch(part); match != nil {
+			from, _ := strconv.Atoi(match[1])
+			to, _ := strconv.Atoi(match[2])
+			if from > 24 || to > 24 || from%24 == to%24 {
+				return d, false
+			}
+
+			from, to = from%24, to%24
+
+			d.HoursFrom, d.HoursTo = &from, &to
+			continue
+		}
+
+		weekdays, ok := m.parseWeekdays(part)
+		if !ok {
+			return d, false
+		}
+		d.Weekdays = append(d.Weekdays, weekdays...)
+	}
+
+	return d, true
+}
+
+func parseDate(day, month, year string, defaultYear int) (time.Time, bool) {
+	d, _ := strconv.Atoi(day)
+	mo, _ := strconv.Atoi(month)
+	y := defaultYear
+	if year != "" {
+		y, _ = strconv.Atoi(year)
+	}
+
+	t := time.Date(y, time.Month(mo), d, 0, 0, 0, 0, time.UTC)
+	// time.Date normalizes 31.02 into March, such dates are invalid
+	return t, t.Day() == d && int(t.Month()) == mo
+}
+
+// parseWeekdays parses weekdays separated by spaces and ranges of them, e.g. "пн-пт" or "сб нд".
+// Abbreviations of any supported language are accepted.
+func (m *MessageHandler) parseWeekdays(s string) ([]time.Weekday, bool) {
+	weekdays := make([]time.Weekday, 0)
+	for _, token := range strings.Fields(strings.ReplaceAll(s, "-", " - ")) {
+		if token == "-" {
+			if len(weekdays) == 0 {
+				return nil, false
+			}
+			weekdays = append(weekdays, -1) // range marker, resolved by the next weekday
+			continue
+		}
+
+		wd, ok := m.weekdayByName(token)
+		if !ok {
+			return nil, false
+		}
+
+		n := len(weekdays)
+		if n < 2 || weekdays[n-1] != -1 {
+			weekdays = append(weekdays, wd)
+			continue
+		}
+
+		from := weekdays[n-2]
+		weekdays = weekdays[:n-1]
+		for d := (from + 1) % 7; ; d = (d + 1) % 7 {
+			weekdays = append(weekdays, d)
+			if d == wd {
+				break
+			}
+		}
+	}
+
+	if len(weekdays) == 0 || weekdays[len(weekdays)-1] == -1 {
+		return nil, false
+	}
+	return weekdays, true
+}
+
+func (m *MessageHandler) weekdayByName(name string) (time.Weekday, bool) {
+	for _, lang := range []string{UALang, "RU", "EN"} {
+		for wd := time.Sunday; wd <= time.Saturday; wd++ {
+			if m.Localize.WeekDayShort(wd, lang) == name {
+				return wd, true
+			}
+		}
+	}
+	return 0, false
+}
+
+// sendVolunteerDetailsMenu offers optional structured fields before the help is published.
+func (m *MessageHandler) sendVolunteerDetailsMenu(u *Update) error {
+	d := m.dialogs.get(u.dialogKey())
+	d.next = m.handleVolunteerDetailsReply
+
+	if d.volunteer.organisations == nil {
+		uid, err := u.userUUID()
+		if err != nil {
+			return err
+		}
+
+		d.volunteer.organisations, err = m.Service.UserOrganisations(u.ctx, uid)
+		if err != nil {
+			return err
+		}
+	}
+
+	txt := m.Localize.Translate(volunteerDetailsRequestTr, u.lang())
+	details := m.detailsText(d.volunteer.details, u.lang())
+	if o := d.volunteer.organisation; o != nil {
+		details = m.organisationText(&service.HelpOrganisation{Name: o.Name, Verified: o.Verified}, u.lang()) + details
+	}
+	if details != "" {
+		txt = fmt.Sprintf("%s\n\n%s", details, txt)
+	}
+
+	buttons := []tg.KeyboardButton{
+		{Text: m.Localize.Translate(btnOptionCapacityTr, u.lang())},
+		{Text: m.Localize.Translate(btnOptionAvailabilityTr, u.lang())},
+		{Text: m.Localize.Translate(btnOptionLanguagesTr, u.lang())},
+	}
+	if len(d.volunteer.organisations) > 0 {
+		buttons = append(buttons, tg.KeyboardButton{Text: m.Localize.Translate(btnOptionOrganisationTr, u.lang())})
+	}
+
+	msg := tg.NewMessage(u.chatID(), txt)
+	msg.ReplyMarkup = tg.ReplyKeyboardMarkup{
+		Keyboard: [][]tg.KeyboardButton{
+			buttons,
+			{
+				{Text: m.Localize.Translate(btnOptionCancelTr, u.lang())},
+				{Text: m.Localize.Translate(btnOptionPublishTr, u.lang())},
+			},
+		},
+		ResizeKeyboard: true,
+	}
+	_, err := m.Api.Send(msg)
+	return err
+}
+
+func (m *MessageHandler) handleVolunteerDetailsReply(u *Update) error {
+	switch u.Message.Text {
+	case m.Localize.Translate(btnOptionCapacityTr, u.lang()):
+		return m.sendCapacityUnitRequest(u)
+	case m.Localize.Translate(btnOptionAvailabilityTr, u.lang()):
+		return m.sendAvailabilityRequest(u)
+	case m.Localize.Translate(btnOptionLanguagesTr, u.lang()):
+		return m.sendLanguagesRequest(u)
+	case m.Localize.Translate(btnOptionOrganisationTr, u.lang()):
+		if len(m.dialogs.get(u.dialogKey()).volunteer.organisations) > 0 {
+			return m.sendVolunteerOrganisationRequest(u)
+		}
+	case m.Localize.Translate(btnOptionPublishTr, u.lang()):
+		return m.publishVolunteerHelp(u)
+	}
+
+	_, err := m.Api.Send(tg.NewMessage(u.chatID(), m.Localize.Translate(errorChooseOptionTr, u.lang())))
+	return err
+}
+
+func (m *MessageHandler) sendCapacityUnitRequest(u *Update) error {
+	units := make([]tg.KeyboardButton, 0, len(capacityUnits))
+	for _, cu := range capacityUnits {
+		units = append(units, tg.KeyboardButton{Text: m.Localize.Translate(cu.btn, u.lang())})
+	}
+
+	msg := tg.NewMessage(u.chatID(), m.Localize.Translate(volunteerCapacityUnitRequestTr, u.lang()))
+	msg.ReplyMarkup = tg.ReplyKeyboardMarkup{
+		Keyboard:       [][]tg.KeyboardButton{units, {{Text: m.Localize.Translate(btnOptionBackTr, u.lang())}}},
+		ResizeKeyboard: true,
+	}
+	_, err := m.Api.Send(msg)
+	if err != nil {
+		return err
+	}
+
+	m.dialogs.get(u.dialogKey()).next = m.handleVolunteerCapacityUnitReply
+	return nil
+}
+
+func (m *MessageHandler) handleVolunteerCapacityUnitReply(u *Update) error {
+	if u.Message.Text == m.Localize.Translate(btnOptionBackTr, u.lang()) {
+		return m.sendVolunteerDetailsMenu(u)
+	}
+
+	for _, cu := range capacityUnits {
+		if u.Message.Text != m.Localize.Translate(cu.btn, u.lang()) {
+			continue
+		}
+
+		msg := tg.NewMessage(u.chatID(), m.Localize.Translate(volunteerCapacityRequestTr, u.lang()))
+		msg.ReplyMarkup = tg.ReplyKeyboardMarkup{
+			Keyboard:       [][]tg.KeyboardButton{{{Text: m.Localize.Translate(btnOptionBackTr, u.lang())}}},
+			ResizeKeyboard: true,
+		}
+		_, err := m.Api.Send(msg)
+		if err != nil {
+			return err
+		}
+
+		d := m.dialogs.get(u.dialogKey())
+		d.volunteer.details.CapacityUnit = cu.unit
+		d.next = m.handleVolunteerCapacityReply
+		return nil
+	}
+
+	_, err := m.Api.Send(tg.NewMessage(u.chatID(), m.Localize.Translate(errorChooseOptionTr, u.lang())))
+	return err
+}
+
+// handleVolunteerCapacityReply sets capacity, zero removes it.
+func (m *MessageHandler) handleVolunteerCapacityReply(u *Update) error {
+	if u.Message.Text == m.Localize.Translate(btnOptionBackTr, u.lang()) {
+		return m.sendVolunteerDetailsMenu(u)
+	}
+
+	capacity, err := strconv.Atoi(strings.TrimSpace(u.Message.Text))
+	if err != nil || capacity < 0 || capacity > service.MaxCapacity {
+		_, err = m.Api.Send(tg.NewMessage(u.chatID(), m.Localize.Translate(errorPleaseTryAgainTr, u.lang())))
+		return err
+	}
+
+	m.dialogs.get(u.dialogKey()).volunteer.details.Capacity = capacity
+	return m.sendVolunteerDetailsMenu(u)
+}
+
+func (m *MessageHandler) sendAvailabilityRequest(u *Update) error {
+	msg := tg.NewMessage(u.chatID(), m.Localize.Translate(volunteerAvailabilityRequestTr, u.lang()))
+	msg.ReplyMarkup = tg.ReplyKeyboardMarkup{
+		Keyboard:       [][]tg.KeyboardButton{{{Text: m.Localize.Translate(btnOptionBackTr, u.lang())}}},
+		ResizeKeyboard: true,
+	}
+	_, err := m.Api.Send(msg)
+	if err != nil {
+		return err
+	}
+
+	m.dialogs.get(u.dialogKey()).next = m.handleVolunteerAvailabilityReply
+	return nil
+}
+
+// handleVolunteerAvailabilityReply replaces availability of the help with the parsed one.
+func (m *MessageHandler) handleVolunteerAvailabilityReply(u *Update) error {
+	if u.Message.Text == m.Localize.Translate(btnOptionBackTr, u.lang()) {
+		return m.sendVolunteerDetailsMenu(u)
+	}
+
+	a, ok := m.parseAvailability(u.Message.Text, time.Now())
+	if !ok {
+		_, err := m.Api.Send(tg.NewMessage(u.chatID(), m.Localize.Translate(errorAvailabilityFormatTr, u.lang())))
+		return err
+	}
+
+	details := &m.dialogs.get(u.dialogKey()).volunteer.details
+	details.AvailableFrom, details.AvailableTo = a.AvailableFrom, a.AvailableTo
+	details.Weekdays = a.Weekdays
+	details.HoursFrom, details.HoursTo = a.HoursFrom, a.HoursTo
+	return m.sendVolunteerDetailsMenu(u)
+}
+
+func (m *MessageHandler) sendLanguagesRequest(u *Update) error {
+	d := m.dialogs.get(u.dialogKey())
+
+	keyboard := make([][]tg.KeyboardButton, 0, len(service.HelpLanguages)+1)
+	for _, l := range service.HelpLanguages {
+		keyboard = append(keyboard, []tg.KeyboardButton{{Text: languageButtonText(l, d.volunteer.details.Languages)}})
+	}
+	keyboard = append(keyboard, []tg.KeyboardButton{{Text: m.Localize.Translate(btnOptionNextTr, u.lang())}})
+
+	msg := tg.NewMessage(u.chatID(), m.Localize.Translate(volunteerLanguagesRequestTr, u.lang()))
+	msg.ReplyMarkup = tg.ReplyKeyboardMarkup{Keyboard: keyboard, ResizeKeyboard: true}
+	_, err := m.Api.Send(msg)
+	if err != nil {
+		return err
+	}
+
+	d.next = m.handleVolunteerLanguagesReply
+	return nil
+}
+
+func languageButtonText(lang string, chosen []string) string {
+	if hasLanguage(chosen, lang) {
+		return emojiCheckbox + " " + languageNames[lang]
+	}
+	return languageNames[lang]
+}
+
+func hasLanguage(languages []string, lang string) bool {
+	for _, l := range languages {
+		if l == lang {
+			return true
+		}
+	}
+	return false
+}
+
+// handleVolunteerLanguagesReply toggles chosen language, next button goes back to details menu.
+func (m *MessageHandler) handleVolunteerLanguagesReply(u *Update) error {
+	if u.Message.Text == m.Localize.Translate(btnOptionNextTr, u.lang()) {
+		return m.sendVolunteerDetailsMenu(u)
+	}
+
+	details := &m.dialogs.get(u.dialogKey()).volunteer.details
+	for _, lang := range service.HelpLanguages {
+		if u.Message.Text != languageButtonText(lang, details.Languages) {
+			continue
+		}
+
+		languages := make([]string, 0, len(service.HelpLanguages))
+		for _, l := range service.HelpLanguages {
+			if hasLanguage(details.Languages, l) != (l == lang) {
+				languages = append(languages, l)
+			}
+		}
+		details.Languages = languages
+
+		return m.sendLanguagesRequest(u)
+	}
+
+	_, err := m.Api.Send(tg.NewMessage(u.chatID(), m.Localize.Translate(errorChooseOptionTr, u.lang())))
+	return err
+}
@@ -2,6 +2,7 @@ package bot
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 	"sync"
@@ -22,8 +23,54 @@ const (
 	cmdMyHelp          = "my_help"
 	cmdMySubscriptions = "my_subscriptions"
 	cmdSupport         = "support"
+	cmdSearch          = "search"
+
+	cmdCategories     = "categories"
+	cmdCategoryAdd    = "category_add"
+	cmdCategoryRename = "category_rename"
+	cmdCategoryMove   = "category_move"
+	cmdCategoryHide   = "category_hide"
+	cmdCategoryShow   = "category_show"
+	cmdCategoryMerge  = "category_merge"
+	cmdCategoryParent = "category_parent"
+
+	cmdModeration    = "moderation"
+	cmdBannedPhrases = "banned_phrases"
+	cmdBanPhrase     = "ban_phrase"
+	cmdUnbanPhrase   = "unban_phrase"
+	cmdSetTier       = "set_tier"
+
+	cmdOrganisations            = "orgs"
+	cmdOrganisationAdd          = "org_add"
+	cmdOrganisationVerify       = "org_verify"
+	cmdOrganisationUnverify     = "org_unverify"
+	cmdOrganisationMemberAdd    = "org_member_add"
+	cmdOrganisationMemberRemove = "org_member_remove"
+
+	cmdNotifications = "notifications"
+	cmdSettings      = "settings"
+
+	cmdGroupSubscribe     = "group_subscribe"
+	cmdGroupSubscriptions = "group_subscriptions"
 
 	cqHelpsBySubscription = "hepls_by_subscription"
+	cqViewHelp            = "view_help"
+	cqNotificationMode    = "notification_mode"
+	cqQuietHours          = "quiet_hours"
+	cqGroupSubscription   = "group_subscription"
+	cqHelpPage            = "help_page"
+	cqHelpSort            = "help_sort"
+	cqHelpFilter          = "help_filter"
+	cqShowContact         = "show_contact"
+	cqModerationApprove   = "moderation_approve"
+	cqModerationReject    = "moderation_reject"
+	cqFeedback            = "feedback"
+
+	cqSettingsLanguage      = "settings_lang"
+	cqSettingsNotifications = "settings_notifications"
+	cqSettingsRadius        = "settings_radius"
+	cqSettingsLocality      = "settings_locality"
+	cqSettingsContact       = "settings_contact"
 )
 
 const (
@@ -31,9 +78,14 @@ const (
 	emojiItem     = "🔸"
 	emojiLocation = "🏡"
 	emojiTime     = "⏱"
+	emojiHidden   = "🙈"
+	emojiFolder   = "📂"
+	emojiKeywords = "🔑"
+	emojiAttached = "📎"
 )
 
-const adminTgID = 386274487
+// adminLang is the language moderation requests are sent to admin in.
+const adminLang = UALang
 
 type (
 	role    int
@@ -45,30 +97,91 @@ type (
 		// either one is populated during the dialog
 		volunteer *volunteer
 		seeker    *seeker
+		settings  *settings
+
+		shortcuts service.Localities // localities offered at the locality step
 	}
 )
 
+// dialogKey identifies a dialog, group members have their own dialogs in the same chat.
+type dialogKey struct {
+	chatID int64
+	userID int
+}
+
 type dialogs struct {
 	mu    *sync.Mutex
-	state map[int64]*dialog
+	state map[dialogKey]*dialog
+	locks map[dialogKey]*dialogLock
+}
+
+// dialogLock serializes updates of one dialog, refs counts holders and waiters
+// so the lock is dropped from the map once nobody needs it.
+type dialogLock struct {
+	mu   sync.Mutex
+	refs int
+}
+
+// lock blocks until no other update of the dialog is handled and returns the unlock func.
+// Updates are handled concurrently, without it album items race on the same dialog state.
+func (d *dialogs) lock(key dialogKey) func() {
+	d.mu.Lock()
+	l, ok := d.locks[key]
+	if !ok {
+		l = &dialogLock{}
+		d.locks[key] = l
+	}
+	l.refs++
+	d.mu.Unlock()
+
+	l.mu.Lock()
+	return func() {
+		l.mu.Unlock()
+		d.mu.Lock()
+		defer d.mu.Unlock()
+		l.refs--
+		if l.refs == 0 {
+			delete(d.locks, key)
+		}
+	}
 }
 
-func (d *dialogs) set(dialog *dialog, chatID int64) {
+func (d *dialogs) set(dialog *dialog, key dialogKey) {
 	d.mu.Lock()
 	defer d.mu.Unlock()
-	d.state[chatID] = dialog
+	d.state[key] = dialog
 }
 
-func (d *dialogs) get(chatID int64) *dialog {
+func (d *dialogs) get(key dialogKey) *dialog {
 	d.mu.Lock()
 	defer d.mu.Unlock()
-	return d.state[chatID]
+	return d.state[key]
 }
 
-func (d *dialogs) delete(chatID int64) {
+func (d *dialogs) delete(key dialogKey) {
 	d.mu.Lock()
 	defer d.mu.Unlock()
-	delete(d.state, chatID)
+	delete(d.state, key)
+}
+
+// categoryCache keeps categories loaded from the service until they are updated.
+type categoryCache struct {
+	mu  *sync.RWMutex
+	all service.Categories
+}
+
+func (c *categoryCache) set(cs service.Categories) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.all = cs
+}
+
+// translated returns visible categories translated to lang.
+func (c *categoryCache) translated(lang string) service.CategoriesTranslated {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	visible := c.all.Visible()
+	return visible.Translate(lang)
 }
 
 type MessageHandler struct {
@@ -78,7 +191,8 @@ type MessageHandler struct {
 	Service  *service.Service
 
 	dialogs    *dialogs
-	categories service.CategoriesTranslated
+	pagers     *pagers
+	categories *categoryCache
 }
 
 func NewMessageHandler(ctx context.Context, api *tg.BotAPI, l *zap.Logger, s *service.Service, tr *Localizer) (*MessageHandler, error) {
@@ -87,7 +201,10 @@ func NewMessageHandler(ctx context.Context, api *tg.BotAPI, l *zap.Logger, s *se
 		L:        l,
 		Localize: tr,
 		Service:  s,
-		dialogs:  &dialogs{mu: &sync.Mutex{}, state: make(map[int64]*dialog)},
+		dialogs:  &dialogs{mu: &sync.Mutex{}, state: make(map[dialogKey]*dialog), locks: make(map[dialogKey]*dialogLock)},
+		pagers:   &pagers{mu: &sync.Mutex{}, state: make(map[pagerKey]*helpPager)},
+
+		categories: &categoryCache{mu: &sync.RWMutex{}},
 	}
 
 	categories, err := s.GetCategories(ctx)
@@ -95,29 +212,53 @@ func NewMessageHandler(ctx context.Context, api *tg.BotAPI, l *zap.Logger, s *se
 		return nil, err
 	}
 
-	m.categories = categories.Translate(UALang)
+	m.categories.set(categories)
 	go m.listenSubscriptionUpdates(ctx)
+	go m.listenDigests(ctx)
+	go m.listenCategoryUpdates(ctx)
+	go m.listenHelpEvents(ctx)
+	go m.listenModerationEvents(ctx)
+	go m.listenFeedbackRequests(ctx)
 	return m, nil
 }
 
+func (m *MessageHandler) listenCategoryUpdates(ctx context.Context) {
+	for {
+		select {
+		case <-m.Service.CategoriesUpdated():
+			categories, err := m.Service.GetCategories(ctx)
+			if err != nil {
+				m.L.Error("reload categories", zap.Error(err))
+				continue
+			}
+			m.categories.set(categories)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
 func (m *MessageHandler) listenSubscriptionUpdates(ctx context.Context) {
 	for {
 		select {
 		case upd := <-m.Service.Subscriptions():
-			for _, u := range upd {
-				var b strings.Builder
-				b.WriteString(fmt.Sprintf("%s\n\n", m.Localize.Translate(seekerSubscriptionUpdateHeaderTr, UALang)))
-				b.WriteString(fmt.Sprintf("%s %s\n", emojiLocation, u.Locality))
-				b.WriteString(fmt.Sprintf("%s %s\n", emojiTime, m.Localize.FormatDateTime(u.CreatedAt, UALang)))
-				for _, c := range u.Categories {
-					b.WriteString(fmt.Sprintf("%s %s\n", emojiItem, c))
+			for _, sm := range upd {
+				err := m.sendAttachments(sm.ChatID, sm.Attachments)
+				if err != nil {
+					m.L.Error("send subscription update attachments", zap.Error(err), zap.Int64("chat_id", sm.ChatID))
+					continue
+				}
+
+				txt := fmt.Sprintf("%s\n\n%s", m.Localize.Translate(seekerSubscriptionUpdateHeaderTr, sm.Language), m.helpText(sm.UserHelp, sm.Language))
+				msg := tg.NewMessage(sm.ChatID, txt)
+				if sm.HasContact {
+					msg.ReplyMarkup = m.contactKeyboard(sm.ID, sm.Language)
 				}
-				b.WriteString(fmt.Sprintf("%s\n\n", u.Description))
-				msg := tg.NewMessage(u.ChatID, b.String())
-				_, err := m.Api.Send(msg)
+				_, err = m.Api.Send(msg)
 				if err != nil {
-					m.L.Error("send subscription update", zap.Error(err))
-					return
+					// bot may be removed from a group, other chats still get the update
+					m.L.Error("send subscription update", zap.Error(err), zap.Int64("chat_id", sm.ChatID))
+					continue
 				}
 			}
 		case <-ctx.Done():
@@ -127,6 +268,16 @@ func (m *MessageHandler) listenSubscriptionUpdates(ctx context.Context) {
 }
 
 func (m *MessageHandler) Handle(_ *tg.BotAPI, u *Update) {
+	if u.InlineQuery != nil {
+		err := m.handleInlineQuery(u)
+		if err != nil {
+			m.L.Error("handle inline query", zap.Error(err))
+		}
+		return
+	}
+
+	defer m.dialogs.lock(u.dialogKey())()
+
 	if u.CallbackQuery != nil {
 		err := m.handleCallbackQuery(u)
 		if err != nil {
@@ -136,7 +287,16 @@ func (m *MessageHandler) Handle(_ *tg.BotAPI, u *Update) {
 	}
 
 	if u.Message != nil && u.Message.IsCommand() {
-		m.dialogs.delete(u.chatID())
+		if !m.addressedToBot(u) {
+			return
+		}
+
+		m.dialogs.delete(u.dialogKey())
+		if !u.isPrivate() {
+			m.handleGroupCmd(u)
+			return
+		}
+
 		switch u.Message.Command() {
 		case cmdStart:
 			err := m.handleCmdStart(u)
@@ -162,12 +322,77 @@ func (m *MessageHandler) Handle(_ *tg.BotAPI, u *Update) {
 				m.L.Error("handle cmd", zap.Error(err), zap.String("cmd", cmdMyHelp))
 			}
 			return
+		case cmdSearch:
+			err := m.handleCmdSearch(u)
+			if err != nil {
+				m.L.Error("handle cmd", zap.Error(err), zap.String("cmd", cmdSearch))
+			}
+			return
+		case cmdNotifications:
+			err := m.handleCmdNotifications(u)
+			if err != nil {
+				m.L.Error("handle cmd", zap.Error(err), zap.String("cmd", cmdNotifications))
+			}
+			return
+		case cmdSettings:
+			err := m.handleCmdSettings(u)
+			if err != nil {
+				m.L.Error("handle cmd", zap.Error(err), zap.String("cmd", cmdSettings))
+			}
+			return
+		case cmdGroupSubscribe, cmdGroupSubscriptions:
+			_, err := m.Api.Send(tg.NewMessage(u.chatID(), m.Localize.Translate(errorGroupChatOnlyTr, u.lang())))
+			if err != nil {
+				m.L.Error("handle cmd", zap.Error(err), zap.String("cmd", u.Message.Command()))
+			}
+			return
+		case cmdCategories, cmdCategoryAdd, cmdCategoryRename, cmdCategoryMove, cmdCategoryHide, cmdCategoryShow, cmdCategoryMerge, cmdCategoryParent:
+			if !u.isAdmin() {
+				break
+			}
+			err := m.handleCmdAdminCategory(u)
+			if err != nil {
+				m.L.Error("handle cmd", zap.Error(err), zap.String("cmd", u.Message.Command()))
+			}
+			return
+		case cmdModeration, cmdBannedPhrases, cmdBanPhrase, cmdUnbanPhrase:
+			if !u.isAdmin() {
+				break
+			}
+			err := m.handleCmdAdminModeration(u)
+			if err != nil {
+				m.L.Error("handle cmd", zap.Error(err), zap.String("cmd", u.Message.Command()))
+			}
+			return
+		case cmdSetTier:
+			if !u.isAdmin() {
+				break
+			}
+			err := m.handleCmdAdminTier(u)
+			if err != nil {
+				m.L.Error("handle cmd", zap.Error(err), zap.String("cmd", cmdSetTier))
+			}
+			return
+		case cmdOrganisations, cmdOrganisationAdd, cmdOrganisationVerify, cmdOrganisationUnverify, cmdOrganisationMemberAdd, cmdOrganisationMemberRemove:
+			if !u.isAdmin() {
+				break
+			}
+			err := m.handleCmdAdminOrganisation(u)
+			if err != nil {
+				m.L.Error("handle cmd", zap.Error(err), zap.String("cmd", u.Message.Command()))
+			}
+			return
 		}
 	}
 
-	if u.Message.Text == m.Localize.Translate(btnOptionCancelTr, UALang) {
-		m.dialogs.delete(u.chatID())
-		msg := tg.NewMessage(u.chatID(), m.Localize.Translate(navigationHintTr, UALang))
+	// in groups the bot answers to members who started a dialog only
+	if !u.isPrivate() && m.dialogs.get(u.dialogKey()) == nil {
+		return
+	}
+
+	if u.Message.Text == m.Localize.Translate(btnOptionCancelTr, u.lang()) {
+		m.dialogs.delete(u.dialogKey())
+		msg := tg.NewMessage(u.chatID(), m.Localize.Translate(navigationHintTr, u.lang()))
 		msg.ReplyMarkup = tg.ReplyKeyboardHide{HideKeyboard: true}
 		_, err := m.Api.Send(msg)
 		if err != nil {
@@ -176,7 +401,7 @@ func (m *MessageHandler) Handle(_ *tg.BotAPI, u *Update) {
 		return
 	}
 
-	dialog := m.dialogs.get(u.chatID())
+	dialog := m.dialogs.get(u.dialogKey())
 	if dialog == nil {
 		err := m.handleCmdStart(u)
 		if err != nil {
@@ -199,6 +424,16 @@ func (m *MessageHandler) handleCallbackQuery(u *Update) error {
 		return fmt.Errorf("invalid callbackquery")
 	}
 
+	// contact is answered with an alert outside the private chat
+	if qslice[0] == cqShowContact {
+		return m.handleShowContactCallback(u, qslice[1])
+	}
+
+	_, err := m.Api.AnswerCallbackQuery(tg.NewCallback(u.CallbackQuery.ID, ""))
+	if err != nil {
+		m.L.Error("answer callback query", zap.Error(err))
+	}
+
 	switch qslice[0] {
 	case cmdMyHelp: // delete help
 		uid, err := uuid.Parse(qslice[1])
@@ -207,11 +442,15 @@ func (m *MessageHandler) handleCallbackQuery(u *Update) error {
 		}
 
 		err = m.Service.DeleteHelp(u.ctx, uid)
+		if errors.Is(err, service.ErrNotFound) {
+			_, err = m.Api.Send(tg.NewMessage(u.chatID(), m.Localize.Translate(errorHelpDoesNotExistTr, u.lang())))
+			return err
+		}
 		if err != nil {
-			return fmt.Errorf("parse uuid: %w", err)
+			return fmt.Errorf("delete help: %w", err)
 		}
 
-		msg := tg.NewMessage(u.chatID(), fmt.Sprintf("%s.\n\n%s", m.Localize.Translate(deleteHelpSuccessTr, UALang), m.Localize.Translate(navigationHintTr, UALang)))
+		msg := tg.NewMessage(u.chatID(), fmt.Sprintf("%s.\n\n%s", m.Localize.Translate(deleteHelpSuccessTr, u.lang()), m.Localize.Translate(navigationHintTr, u.lang())))
 		msg.ReplyMarkup = tg.ReplyKeyboardHide{HideKeyboard: true}
 		_, err = m.Api.Send(msg)
 		return err
@@ -228,7 +467,7 @@ func (m *MessageHandler) handleCallbackQuery(u *Update) error {
 		}
 
 		if !ok {
-			msg := tg.NewMessage(u.chatID(), fmt.Sprintf("%s.\n\n%s", m.Localize.Translate(errorSubscriptionDoesNotExistTr, UALang), m.Localize.Translate(navigationHintTr, UALang)))
+			msg := tg.NewMessage(u.chatID(), fmt.Sprintf("%s.\n\n%s", m.Localize.Translate(errorSubscriptionDoesNotExistTr, u.lang()), m.Localize.Translate(navigationHintTr, u.lang())))
 			msg.ReplyMarkup = tg.ReplyKeyboardHide{HideKeyboard: true}
 			_, err = m.Api.Send(msg)
 			return err
@@ -239,7 +478,7 @@ func (m *MessageHandler) handleCallbackQuery(u *Update) error {
 			return fmt.Errorf("parse uuid: %w", err)
 		}
 
-		msg := tg.NewMessage(u.chatID(), fmt.Sprintf("%s.\n\n%s", m.Localize.Translate(deleteSubscriptionSuccessTr, UALang), m.Localize.Translate(navigationHintTr, UALang)))
+		msg := tg.NewMessage(u.chatID(), fmt.Sprintf("%s.\n\n%s", m.Localize.Translate(deleteSubscriptionSuccessTr, u.lang()), m.Localize.Translate(navigationHintTr, u.lang())))
 		msg.ReplyMarkup = tg.ReplyKeyboardHide{HideKeyboard: true}
 		_, err = m.Api.Send(msg)
 		return err
@@ -256,64 +495,112 @@ func (m *MessageHandler) handleCallbackQuery(u *Update) error {
 		}
 
 		if !ok {
-			msg := tg.NewMessage(u.chatID(), fmt.Sprintf("%s.\n\n%s", m.Localize.Translate(errorSubscriptionDoesNotExistTr, UALang), m.Localize.Translate(navigationHintTr, UALang)))
+			msg := tg.NewMessage(u.chatID(), fmt.Sprintf("%s.\n\n%s", m.Localize.Translate(errorSubscriptionDoesNotExistTr, u.lang()), m.Localize.Translate(navigationHintTr, u.lang())))
 			msg.ReplyMarkup = tg.ReplyKeyboardHide{HideKeyboard: true}
 			_, err = m.Api.Send(msg)
 			return err
 		}
 
-		helps, err := m.Service.HelpsBySubscription(u.ctx, sid)
-		if err != nil {
+		ok, err = m.sendHelpPager(u, newHelpPager(func(ctx context.Context, after *service.Cursor) (service.HelpPage, error) {
+			return m.Service.HelpsBySubscription(ctx, sid, after, helpsPerPage)
+		}, false))
+		if err != nil || ok {
 			return err
 		}
 
-		if len(helps) == 0 {
-			msg := tg.NewMessage(u.chatID(), fmt.Sprintf("%s.\n\n%s", m.Localize.Translate(seekerHelpsEmptyTr, UALang), m.Localize.Translate(navigationHintTr, UALang)))
-			msg.ReplyMarkup = tg.ReplyKeyboardHide{HideKeyboard: true}
-			_, err = m.Api.Send(msg)
-			return err
+		msg := tg.NewMessage(u.chatID(), fmt.Sprintf("%s.\n\n%s", m.Localize.Translate(seekerHelpsEmptyTr, u.lang()), m.Localize.Translate(navigationHintTr, u.lang())))
+		msg.ReplyMarkup = tg.ReplyKeyboardHide{HideKeyboard: true}
+		_, err = m.Api.Send(msg)
+		return err
+
+	case cqViewHelp:
+		hid, err := uuid.Parse(qslice[1])
+		if err != nil {
+			return fmt.Errorf("parse help id: %w", err)
 		}
 
-		for _, help := range helps {
-			var b strings.Builder
-			b.WriteString(fmt.Sprintf("%s %s\n", emojiLocation, help.Locality))
-			b.WriteString(fmt.Sprintf("%s %s\n", emojiTime, m.Localize.FormatDateTime(help.CreatedAt, UALang)))
-			for _, c := range help.Categories {
-				b.WriteString(fmt.Sprintf("%s %s\n", emojiItem, c))
-			}
-			b.WriteString(fmt.Sprintf("%s\n\n", help.Description))
-			msg := tg.NewMessage(u.chatID(), b.String())
-			msg.ReplyMarkup = tg.ReplyKeyboardHide{HideKeyboard: true}
-			_, err = m.Api.Send(msg)
-			if err != nil {
-				return err
-			}
+		return m.sendHelp(u, hid)
+
+	case cqNotificationMode, cqQuietHours:
+		return m.handleNotificationsCallback(u, qslice[0], qslice[1])
+
+	case cqHelpPage:
+		return m.handleHelpPageCallback(u, qslice[1])
+
+	case cqHelpSort, cqHelpFilter:
+		return m.handleHelpQueryCallback(u, qslice[0], qslice[1])
+
+	case cqGroupSubscription:
+		return m.handleGroupSubscriptionCallback(u, qslice[1])
+
+	case cqSettingsLanguage, cqSettingsNotifications, cqSettingsRadius, cqSettingsLocality, cqSettingsContact:
+		return m.handleSettingsCallback(u, qslice[0], qslice[1])
+
+	case cqModerationApprove, cqModerationReject:
+		if !u.isAdmin() {
+			return nil
 		}
+		return m.handleModerationCallback(u, qslice[0], qslice[1])
+
+	case cqFeedback:
+		return m.handleFeedbackCallback(u, qslice[1])
 	}
 
 	return nil
 }
 
+// sendHelp sends a single help with its attachments.
+func (m *MessageHandler) sendHelp(u *Update, hid uuid.UUID) error {
+	help, err := m.Service.HelpByID(u.ctx, hid)
+	if errors.Is(err, service.ErrNotFound) {
+		_, err = m.Api.Send(tg.NewMessage(u.chatID(), m.Localize.Translate(errorHelpDoesNotExistTr, u.lang())))
+		return err
+	}
+	if err != nil {
+		return err
+	}
+
+	err = m.sendAttachments(u.chatID(), help.Attachments)
+	if err != nil {
+		return err
+	}
+
+	msg := tg.NewMessage(u.chatID(), m.helpText(help, u.lang()))
+	if help.HasContact {
+		msg.ReplyMarkup = m.contactKeyboard(help.ID, u.lang())
+	}
+	_, err = m.Api.Send(msg)
+	return err
+}
+
 func (m *MessageHandler) handleCmdStart(u *Update) error {
+	if payload := u.Message.CommandArguments(); payload != "" {
+		// unknown payloads such as the inline mode one open the regular start menu
+		link, err := m.Service.ParseStartPayload(payload)
+		if err == nil {
+			return m.handleDeepLink(u, link)
+		}
+	}
+
 	activity, err := m.Service.GetActivityStats(u.ctx)
 	if err != nil {
 		return err
 	}
 
 	var b strings.Builder
-	b.WriteString(fmt.Sprintf("%s\n", m.Localize.Translate(cmdStartActivityHeaderTr, UALang)))
-	b.WriteString(fmt.Sprintf("%s %d\n", m.Localize.Translate(cmdStartActivityHelpsTr, UALang), activity.ActiveHelpsCount))
-	b.WriteString(fmt.Sprintf("%s %d\n\n", m.Localize.Translate(cmdStartActivitySubscriptionsTr, UALang), activity.ActiveSubsCount))
-	b.WriteString(m.Localize.Translate(userRoleRequestTr, UALang))
+	b.WriteString(fmt.Sprintf("%s\n", m.Localize.Translate(cmdStartActivityHeaderTr, u.lang())))
+	b.WriteString(fmt.Sprintf("%s %d\n", m.Localize.Translate(cmdStartActivityHelpsTr, u.lang()), activity.ActiveHelpsCount))
+	b.WriteString(fmt.Sprintf("%s %d\n\n", m.Localize.Translate(cmdStartActivitySubscriptionsTr, u.lang()), activity.ActiveSubsCount))
+	b.WriteString(m.Localize.Translate(userRoleRequestTr, u.lang()))
 
 	msg := tg.NewMessage(u.chatID(), b.String())
 	msg.ReplyMarkup = tg.ReplyKeyboardMarkup{
 		OneTimeKeyboard: false,
 		ResizeKeyboard:  true,
 		Keyboard: [][]tg.KeyboardButton{
-			{tg.KeyboardButton{Text: m.Localize.Translate(btnOptionRoleSeekerTr, UALang)}},
-			{tg.KeyboardButton{Text: m.Localize.Translate(btnOptionUserVolunteerTr, UALang)}},
-			{tg.KeyboardButton{Text: m.Localize.Translate(btnOptionCancelTr, UALang)}},
+			{tg.KeyboardButton{Text: m.Localize.Translate(btnOptionRoleSeekerTr, u.lang())}},
+			{tg.KeyboardButton{Text: m.Localize.Translate(btnOptionUserVolunteerTr, u.lang())}},
+			{tg.KeyboardButton{Text: m.Localize.Translate(btnOptionCancelTr, u.lang())}},
 		},
 	}
 
@@ -322,18 +609,18 @@ func (m *MessageHandler) handleCmdStart(u *Update) error {
 		return err
 	}
 
-	m.dialogs.set(&dialog{next: m.handleUserRoleReply}, u.chatID())
+	m.dialogs.set(&dialog{next: m.handleUserRoleReply}, u.dialogKey())
 	return nil
 }
 
 func (m *MessageHandler) handleUserRoleReply(u *Update) error {
 	switch u.Message.Text {
-	case m.Localize.Translate(btnOptionRoleSeekerTr, UALang):
+	case m.Localize.Translate(btnOptionRoleSeekerTr, u.lang()):
 		return m.handleSeekerUserRoleReply(u)
-	case m.Localize.Translate(btnOptionUserVolunteerTr, UALang):
+	case m.Localize.Translate(btnOptionUserVolunteerTr, u.lang()):
 		return m.handleVolunteerUserRoleReply(u)
 	default:
-		_, err := m.Api.Send(tg.NewMessage(u.chatID(), m.Localize.Translate(errorChooseOptionTr, UALang)))
+		_, err := m.Api.Send(tg.NewMessage(u.chatID(), m.Localize.Translate(errorChooseOptionTr, u.lang())))
 		if err != nil {
 			return err
 		}
@@ -343,7 +630,7 @@ func (m *MessageHandler) handleUserRoleReply(u *Update) error {
 }
 
 func (m *MessageHandler) handleCmdSupport(u *Update) error {
-	msg := tg.NewMessage(u.chatID(), fmt.Sprintf("%s\n\n%s", m.Localize.Translate(cmdSupportTr, UALang), m.Localize.Translate(navigationHintTr, UALang)))
+	msg := tg.NewMessage(u.chatID(), fmt.Sprintf("%s\n\n%s", m.Localize.Translate(cmdSupportTr, u.lang()), m.Localize.Translate(navigationHintTr, u.lang())))
 	msg.ReplyMarkup = tg.ReplyKeyboardHide{HideKeyboard: true}
 	_, err := m.Api.Send(msg)
 	return err
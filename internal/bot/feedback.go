@@ -0,0 +1,93 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	tg "github.com/go-telegram-bot-api/telegram-bot-api"
+	"github.com/google/uuid"
+	"github.com/rvkinc/uasocial/internal/service"
+	"go.uber.org/zap"
+)
+
+const (
+	emojiPositive = "👍"
+	emojiNegative = "👎"
+
+	feedbackSnippetLength = 100
+)
+
+// reputationText renders feedback counts of the volunteer, empty if there is no feedback yet.
+func reputationText(r service.Reputation) string {
+	if r.Positive == 0 && r.Negative == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%s %d %s %d\n", emojiPositive, r.Positive, emojiNegative, r.Negative)
+}
+
+func (m *MessageHandler) listenFeedbackRequests(ctx context.Context) {
+	for {
+		select {
+		case r := <-m.Service.FeedbackRequests():
+			err := m.sendFeedbackRequest(r)
+			if err != nil {
+				m.L.Error("send feedback request", zap.Error(err), zap.Stringer("help_id", r.HelpID))
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// sendFeedbackRequest asks the seeker whether they received the help.
+func (m *MessageHandler) sendFeedbackRequest(r service.FeedbackRequest) error {
+	button := func(tr, answer string) tg.InlineKeyboardButton {
+		return tg.NewInlineKeyboardButtonData(m.Localize.Translate(tr, r.Language), fmt.Sprintf("%s|%s:%s", cqFeedback, r.HelpID, answer))
+	}
+
+	msg := tg.NewMessage(r.ChatID, fmt.Sprintf(m.Localize.Translate(feedbackRequestTr, r.Language), snippet(r.Description, feedbackSnippetLength)))
+	msg.ReplyMarkup = tg.NewInlineKeyboardMarkup(tg.NewInlineKeyboardRow(
+		button(btnOptionFeedbackYesTr, service.FeedbackYes),
+		button(btnOptionFeedbackNoTr, service.FeedbackNo),
+		button(btnOptionFeedbackReportTr, service.FeedbackReport),
+	))
+	_, err := m.Api.Send(msg)
+	return err
+}
+
+// handleFeedbackCallback saves the answer and replaces the buttons with the result.
+func (m *MessageHandler) handleFeedbackCallback(u *Update, value string) error {
+	v := strings.SplitN(value, ":", 2)
+	if len(v) != 2 {
+		return fmt.Errorf("invalid feedback: %s", value)
+	}
+
+	hid, err := uuid.Parse(v[0])
+	if err != nil {
+		return fmt.Errorf("parse help id: %w", err)
+	}
+
+	uid, err := u.userUUID()
+	if err != nil {
+		return err
+	}
+
+	result := feedbackThanksTr
+	if v[1] == service.FeedbackReport {
+		result = feedbackReportedTr
+	}
+
+	err = m.Service.LeaveFeedback(u.ctx, hid, uid, v[1])
+	if errors.Is(err, service.ErrNotFound) {
+		result = feedbackDoneTr
+	} else if err != nil {
+		return err
+	}
+
+	cqm := u.CallbackQuery.Message
+	edit := tg.NewEditMessageText(cqm.Chat.ID, cqm.MessageID, fmt.Sprintf("%s\n\n%s", cqm.Text, m.Localize.Translate(result, u.lang())))
+	_, err = m.Api.Send(edit)
+	return err
+}
@@ -0,0 +1,180 @@
+package bot
+
+import (
+	"fmt"
+	"strings"
+
+	tg "github.com/go-telegram-bot-api/telegram-bot-api"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// addressedToBot reports whether command is meant for this bot, groups may have several bots.
+func (m *MessageHandler) addressedToBot(u *Update) bool {
+	cmd := u.Message.CommandWithAt()
+	i := strings.Index(cmd, "@")
+	return i == -1 || strings.EqualFold(cmd[i+1:], m.Api.Self.UserName)
+}
+
+// isChatAdmin reports whether update sender is an admin of the update chat.
+func (m *MessageHandler) isChatAdmin(u *Update) (bool, error) {
+	member, err := m.Api.GetChatMember(tg.ChatConfigWithUser{ChatID: u.chatID(), UserID: u.tgUser().ID})
+	if err != nil {
+		return false, err
+	}
+	return member.IsCreator() || member.IsAdministrator(), nil
+}
+
+// handleGroupCmd handles commands sent to a group chat, personal ones are available in private chat only.
+func (m *MessageHandler) handleGroupCmd(u *Update) {
+	var err error
+	switch u.Message.Command() {
+	case cmdStart:
+		err = m.handleCmdStart(u)
+	case cmdSupport:
+		err = m.handleCmdSupport(u)
+	case cmdSearch:
+		err = m.handleCmdSearch(u)
+	case cmdGroupSubscribe:
+		err = m.handleCmdGroupSubscribe(u)
+	case cmdGroupSubscriptions:
+		err = m.handleCmdGroupSubscriptions(u)
+	case cmdMyHelp, cmdMySubscriptions, cmdNotifications, cmdSettings:
+		_, err = m.Api.Send(tg.NewMessage(u.chatID(), m.Localize.Translate(errorPrivateChatOnlyTr, u.lang())))
+	default:
+		return
+	}
+
+	if err != nil {
+		m.L.Error("handle group cmd", zap.Error(err), zap.String("cmd", u.Message.Command()))
+	}
+}
+
+// sendGroupAdminOnly notifies user that the action requires group admin rights.
+func (m *MessageHandler) sendGroupAdminOnly(u *Update) error {
+	_, err := m.Api.Send(tg.NewMessage(u.chatID(), m.Localize.Translate(errorGroupAdminOnlyTr, u.lang())))
+	return err
+}
+
+func (m *MessageHandler) handleCmdGroupSubscribe(u *Update) error {
+	admin, err := m.isChatAdmin(u)
+	if err != nil {
+		return err
+	}
+
+	if !admin {
+		return m.sendGroupAdminOnly(u)
+	}
+
+	count, err := m.Service.SubscriptionsCountByChat(u.ctx, u.chatID())
+	if err != nil {
+		return err
+	}
+
+	if limit := m.Service.SubscriptionsPerGroup(); count >= limit {
+		_, err = m.Api.Send(tg.NewMessage(u.chatID(), fmt.Sprintf(m.Localize.Translate(groupSubscriptionsLimitExceededTr, u.lang()), limit)))
+		return err
+	}
+
+	chatID := u.chatID()
+	d := &dialog{
+		role:   roleSeeker,
+		seeker: &seeker{categories: m.newCategoryPicker(u.lang()), chatID: &chatID},
+		next:   m.handleSeekerCategoryBtnReply,
+	}
+
+	msg := tg.NewMessage(u.chatID(), m.Localize.Translate(seekerCategoryRequestTr, u.lang()))
+	msg.ReplyMarkup = tg.ReplyKeyboardMarkup{
+		Keyboard:       d.seeker.categories.layout(m.Localize.Translate(btnOptionCancelTr, u.lang()), ""),
+		ResizeKeyboard: true,
+	}
+
+	_, err = m.Api.Send(msg)
+	if err != nil {
+		return err
+	}
+
+	m.dialogs.set(d, u.dialogKey())
+	return nil
+}
+
+func (m *MessageHandler) handleCmdGroupSubscriptions(u *Update) error {
+	subs, err := m.Service.ChatSubscriptions(u.ctx, u.chatID())
+	if err != nil {
+		return fmt.Errorf("get chat subscriptions: %w", err)
+	}
+
+	if len(subs) == 0 {
+		_, err = m.Api.Send(tg.NewMessage(u.chatID(), m.Localize.Translate(groupSubscriptionsEmptyTr, u.lang())))
+		return err
+	}
+
+	for _, s := range subs {
+		var b strings.Builder
+
+		b.WriteString(fmt.Sprintf("%s %s\n", emojiTime, m.Localize.FormatDateTime(s.CreatedAt, u.lang())))
+		b.WriteString(fmt.Sprintf("%s %s\n", emojiLocation, s.Locality))
+		if s.Keywords != "" {
+			b.WriteString(fmt.Sprintf("%s %s\n", emojiKeywords, s.Keywords))
+		}
+		for _, c := range s.Categories {
+			b.WriteString(fmt.Sprintf("%s %s\n", emojiItem, c))
+		}
+
+		deleteQueryString := fmt.Sprintf("%s|%s", cqGroupSubscription, s.ID.String())
+
+		msg := tg.NewMessage(u.chatID(), b.String())
+		msg.ReplyMarkup = tg.InlineKeyboardMarkup{InlineKeyboard: [][]tg.InlineKeyboardButton{{
+			{
+				Text:         m.Localize.Translate(btnOptionDeleteTr, u.lang()),
+				CallbackData: &deleteQueryString,
+			},
+		}}}
+
+		_, err := m.Api.Send(msg)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// handleGroupSubscriptionCallback deletes group subscription, only admins of the group are allowed to.
+func (m *MessageHandler) handleGroupSubscriptionCallback(u *Update, value string) error {
+	sid, err := uuid.Parse(value)
+	if err != nil {
+		return fmt.Errorf("parse uuid: %w", err)
+	}
+
+	admin, err := m.isChatAdmin(u)
+	if err != nil {
+		return err
+	}
+
+	if !admin {
+		return m.sendGroupAdminOnly(u)
+	}
+
+	subs, err := m.Service.ChatSubscriptions(u.ctx, u.chatID())
+	if err != nil {
+		return fmt.Errorf("get chat subscriptions: %w", err)
+	}
+
+	for _, s := range subs {
+		if s.ID != sid {
+			continue
+		}
+
+		err = m.Service.DeleteSubscription(u.ctx, sid)
+		if err != nil {
+			return err
+		}
+
+		_, err = m.Api.Send(tg.NewMessage(u.chatID(), m.Localize.Translate(deleteSubscriptionSuccessTr, u.lang())))
+		return err
+	}
+
+	_, err = m.Api.Send(tg.NewMessage(u.chatID(), m.Localize.Translate(errorSubscriptionDoesNotExistTr, u.lang())))
+	return err
+}
@@ -6,6 +6,7 @@ import (
 
 	tg "github.com/go-telegram-bot-api/telegram-bot-api"
 	"github.com/google/uuid"
+	"github.com/rvkinc/uasocial/internal/service"
 )
 
 type Update struct {
@@ -14,19 +15,62 @@ type Update struct {
 }
 
 func (u *Update) tgUser() *tg.User {
-	if u.CallbackQuery != nil {
+	switch {
+	case u.CallbackQuery != nil:
 		return u.CallbackQuery.From
+	case u.InlineQuery != nil:
+		return u.InlineQuery.From
 	}
 	return u.Message.From
 }
 
+// chatID returns chat of the update, inline queries have no chat,
+// so the private chat with the user is returned.
 func (u *Update) chatID() int64 {
-	if u.CallbackQuery != nil {
+	switch {
+	case u.CallbackQuery != nil:
 		return u.CallbackQuery.Message.Chat.ID
+	case u.InlineQuery != nil:
+		return int64(u.InlineQuery.From.ID)
 	}
 	return u.Message.Chat.ID
 }
 
+// dialogKey returns key of the user dialog in the update chat.
+func (u *Update) dialogKey() dialogKey {
+	return dialogKey{chatID: u.chatID(), userID: u.tgUser().ID}
+}
+
+// isPrivate reports whether update comes from the private chat with the bot.
+func (u *Update) isPrivate() bool {
+	switch {
+	case u.CallbackQuery != nil:
+		return u.CallbackQuery.Message.Chat.IsPrivate()
+	case u.InlineQuery != nil:
+		return true
+	}
+	return u.Message.Chat.IsPrivate()
+}
+
+// user returns user stored in context by the upsert middleware.
+func (u *Update) user() service.User {
+	user, _ := u.ctx.Value(userCtxKey).(service.User)
+	return user
+}
+
+// tier returns user tier, the owner from config always has the admin one.
+func (u *Update) tier() string { return u.user().Tier }
+
+func (u *Update) isAdmin() bool { return u.tier() == service.TierAdmin }
+
+// lang returns user interface language.
+func (u *Update) lang() string {
+	if lang := u.user().Language; lang != "" {
+		return lang
+	}
+	return UALang
+}
+
 func (u *Update) userUUID() (uuid.UUID, error) {
 	v := u.ctx.Value(userIDCtxKey)
 	uid, ok := v.(uuid.UUID)
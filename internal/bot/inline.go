@@ -0,0 +1,53 @@
+package bot
+
+import (
+	"fmt"
+	"strings"
+
+	tg "github.com/go-telegram-bot-api/telegram-bot-api"
+	"github.com/rvkinc/uasocial/internal/service"
+)
+
+const (
+	maxInlineResults     = 50 // telegram limit
+	inlineCacheTime      = 60 // seconds
+	inlineSnippetLength  = 100
+	inlineStartParameter = "inline"
+)
+
+// handleInlineQuery searches helps by "@bot <category> <locality>" query typed in any chat.
+func (m *MessageHandler) handleInlineQuery(u *Update) error {
+	q := u.InlineQuery
+
+	// offset is the cursor of the previous page, invalid one starts from the first page
+	after, _ := service.ParseCursor(q.Offset)
+
+	page, err := m.Service.HelpsByQuery(u.ctx, q.Query, after, maxInlineResults)
+	if err != nil {
+		return err
+	}
+
+	results := make([]interface{}, 0, len(page.Helps))
+	for _, h := range page.Helps {
+		title := fmt.Sprintf("%s — %s", strings.Join(h.Categories, ", "), h.Locality)
+		article := tg.NewInlineQueryResultArticle(h.ID.String(), title, m.helpText(h, u.lang()))
+		article.Description = snippet(h.Description, inlineSnippetLength)
+		results = append(results, article)
+	}
+
+	cfg := tg.InlineConfig{
+		InlineQueryID:     q.ID,
+		Results:           results,
+		CacheTime:         inlineCacheTime,
+		IsPersonal:        true,
+		SwitchPMText:      m.Localize.Translate(inlineSwitchPMTr, u.lang()),
+		SwitchPMParameter: inlineStartParameter,
+	}
+
+	if page.Next != nil {
+		cfg.NextOffset = page.Next.String()
+	}
+
+	_, err = m.Api.AnswerInlineQuery(cfg)
+	return err
+}
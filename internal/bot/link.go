@@ -0,0 +1,76 @@
+package bot
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	tg "github.com/go-telegram-bot-api/telegram-bot-api"
+	"github.com/rvkinc/uasocial/internal/service"
+)
+
+// handleDeepLink opens a help, searches helps or proposes a subscription passed in /start payload.
+func (m *MessageHandler) handleDeepLink(u *Update, link service.DeepLink) error {
+	if link.Kind == service.LinkHelp {
+		return m.sendHelp(u, link.HelpID)
+	}
+
+	d := &dialog{role: roleSeeker, seeker: &seeker{categories: m.newCategoryPicker(u.lang())}}
+	d.seeker.categories.check(link.CategoryIDs)
+
+	l, err := m.Service.LocalityByID(u.ctx, link.LocalityID)
+	if err != nil && !errors.Is(err, service.ErrNotFound) {
+		return err
+	}
+
+	if err != nil || len(d.seeker.categories.selected()) == 0 {
+		// categories or locality were removed since the link was created
+		msg := tg.NewMessage(u.chatID(), fmt.Sprintf("%s\n\n%s", m.Localize.Translate(errorLinkExpiredTr, u.lang()), m.Localize.Translate(navigationHintTr, u.lang())))
+		msg.ReplyMarkup = tg.ReplyKeyboardHide{HideKeyboard: true}
+		_, err = m.Api.Send(msg)
+		return err
+	}
+
+	m.dialogs.set(d, u.dialogKey())
+
+	if link.Kind == service.LinkSearch {
+		return m.handleSeekerLocality(u, l)
+	}
+
+	reached, err := m.subscriptionsLimitReached(u)
+	if err != nil || reached {
+		return err
+	}
+
+	d.seeker.locality = &l
+
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("%s\n\n", m.Localize.Translate(seekerSubscriptionLinkConfirmTr, u.lang())))
+	b.WriteString(fmt.Sprintf("%s %s\n", emojiLocation, l.Name))
+	for _, c := range d.seeker.categories.selected() {
+		b.WriteString(fmt.Sprintf("%s %s\n", emojiItem, c.Name))
+	}
+
+	msg := tg.NewMessage(u.chatID(), b.String())
+	msg.ReplyMarkup = tg.ReplyKeyboardMarkup{
+		Keyboard: [][]tg.KeyboardButton{{
+			{Text: m.Localize.Translate(btnOptionCancelTr, u.lang())},
+			{Text: m.Localize.Translate(btnOptionSubscribeTr, u.lang())},
+		}},
+		OneTimeKeyboard: true,
+		ResizeKeyboard:  true,
+	}
+
+	d.next = m.handleLinkSubscriptionReply
+	_, err = m.Api.Send(msg)
+	return err
+}
+
+func (m *MessageHandler) handleLinkSubscriptionReply(u *Update) error {
+	if u.Message.Text != m.Localize.Translate(btnOptionSubscribeTr, u.lang()) {
+		_, err := m.Api.Send(tg.NewMessage(u.chatID(), m.Localize.Translate(errorChooseOptionTr, u.lang())))
+		return err
+	}
+
+	return m.createSeekerSubscription(u, m.dialogs.get(u.dialogKey()).seeker.locality.ID)
+}
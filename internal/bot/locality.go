@@ -0,0 +1,113 @@
+package bot
+
+import (
+	"errors"
+	"fmt"
+
+	tg "github.com/go-telegram-bot-api/telegram-bot-api"
+	"github.com/rvkinc/uasocial/internal/service"
+	"go.uber.org/zap"
+)
+
+const maxLocalityShortcuts = 3
+
+func localityText(l service.Locality) string { return fmt.Sprintf("%s, %s", l.Name, l.RegionName) }
+
+func shortcutText(l service.Locality) string {
+	return fmt.Sprintf("%s %s", emojiLocation, localityText(l))
+}
+
+// sendLocalityRequest asks user to type the locality offering default
+// and recently used localities and user's location as shortcuts.
+func (m *MessageHandler) sendLocalityRequest(u *Update) error {
+	shortcuts, err := m.localityShortcuts(u)
+	if err != nil {
+		return err
+	}
+
+	keyboard := make([][]tg.KeyboardButton, 0, len(shortcuts)+2)
+	for _, l := range shortcuts {
+		keyboard = append(keyboard, []tg.KeyboardButton{{Text: shortcutText(l)}})
+	}
+	// location can be requested in private chats only
+	if u.isPrivate() {
+		keyboard = append(keyboard, []tg.KeyboardButton{{Text: m.Localize.Translate(btnOptionSendLocationTr, u.lang()), RequestLocation: true}})
+	}
+	keyboard = append(keyboard, []tg.KeyboardButton{{Text: m.Localize.Translate(btnOptionCancelTr, u.lang())}})
+
+	msg := tg.NewMessage(u.chatID(), m.Localize.Translate(userLocalityRequestTr, u.lang()))
+	msg.ReplyMarkup = tg.ReplyKeyboardMarkup{
+		Keyboard:       keyboard,
+		ResizeKeyboard: true,
+	}
+
+	_, err = m.Api.Send(msg)
+	if err != nil {
+		return err
+	}
+
+	m.dialogs.get(u.dialogKey()).shortcuts = shortcuts
+	return nil
+}
+
+// localityShortcuts returns default locality followed by the recently used ones.
+func (m *MessageHandler) localityShortcuts(u *Update) (service.Localities, error) {
+	uid, err := u.userUUID()
+	if err != nil {
+		return nil, err
+	}
+
+	recent, err := m.Service.RecentLocalities(u.ctx, uid, maxLocalityShortcuts)
+	if err != nil {
+		return nil, err
+	}
+
+	shortcuts := make(service.Localities, 0, maxLocalityShortcuts)
+	if l := u.user().Preferences.DefaultLocality; l != nil {
+		shortcuts = append(shortcuts, *l)
+	}
+
+	for _, l := range recent {
+		if len(shortcuts) == maxLocalityShortcuts {
+			break
+		}
+		if len(shortcuts) > 0 && shortcuts[0].ID == l.ID {
+			continue
+		}
+		shortcuts = append(shortcuts, l)
+	}
+
+	return shortcuts, nil
+}
+
+// shortcutLocality returns locality if user pressed one of the locality shortcuts or shared the location.
+func (m *MessageHandler) shortcutLocality(u *Update) (service.Locality, bool, error) {
+	if loc := u.Message.Location; loc != nil {
+		l, err := m.Service.NearestLocality(u.ctx, loc.Latitude, loc.Longitude)
+		if errors.Is(err, service.ErrNotFound) {
+			return service.Locality{}, false, nil
+		}
+		return l, err == nil, err
+	}
+
+	for _, l := range m.dialogs.get(u.dialogKey()).shortcuts {
+		if u.Message.Text == shortcutText(l) {
+			return l, true, nil
+		}
+	}
+
+	return service.Locality{}, false, nil
+}
+
+// rememberLocality stores locality chosen by user to offer it next time.
+func (m *MessageHandler) rememberLocality(u *Update, l service.Locality) {
+	uid, err := u.userUUID()
+	if err != nil {
+		return
+	}
+
+	err = m.Service.RememberLocality(u.ctx, uid, l.ID)
+	if err != nil {
+		m.L.Error("remember locality", zap.Error(err))
+	}
+}
@@ -0,0 +1,168 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	tg "github.com/go-telegram-bot-api/telegram-bot-api"
+	"github.com/google/uuid"
+	"github.com/rvkinc/uasocial/internal/service"
+	"go.uber.org/zap"
+)
+
+func (m *MessageHandler) listenModerationEvents(ctx context.Context) {
+	for {
+		select {
+		case e := <-m.Service.ModerationEvents():
+			err := m.handleModerationEvent(ctx, e)
+			if err != nil {
+				m.L.Error("handle moderation event", zap.Error(err), zap.Stringer("help_id", e.Help.ID))
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (m *MessageHandler) handleModerationEvent(ctx context.Context, e service.ModerationEvent) error {
+	switch e.Kind {
+	case service.HelpHeld:
+		chatIDs, err := m.Service.ModeratorChatIDs(ctx)
+		if err != nil {
+			return err
+		}
+
+		// one unreachable admin shouldn't keep the help from the others
+		for _, chatID := range chatIDs {
+			err = m.sendModerationRequest(chatID, e)
+			if err != nil {
+				m.L.Error("send moderation request", zap.Error(err), zap.Int64("chat_id", chatID))
+			}
+		}
+
+		_, err = m.Api.Send(tg.NewMessage(e.ChatID, m.Localize.Translate(volunteerHelpHeldTr, e.Language)))
+		return err
+	case service.HelpApproved:
+		_, err := m.Api.Send(tg.NewMessage(e.ChatID, fmt.Sprintf(m.Localize.Translate(volunteerHelpApprovedTr, e.Language), m.helpText(e.Help, e.Language))))
+		return err
+	case service.HelpRejected:
+		_, err := m.Api.Send(tg.NewMessage(e.ChatID, fmt.Sprintf(m.Localize.Translate(volunteerHelpRejectedTr, e.Language), m.helpText(e.Help, e.Language))))
+		return err
+	}
+	return nil
+}
+
+// sendModerationRequest sends held help with its score and approve and reject buttons.
+func (m *MessageHandler) sendModerationRequest(chatID int64, e service.ModerationEvent) error {
+	hid := e.Help.ID.String()
+	txt := fmt.Sprintf(m.Localize.Translate(adminModerationHeaderTr, adminLang), e.Score, strings.Join(e.Reasons, ", "))
+	msg := tg.NewMessage(chatID, fmt.Sprintf("%s\n\n%s", txt, m.helpText(e.Help, adminLang)))
+	msg.ReplyMarkup = tg.NewInlineKeyboardMarkup(tg.NewInlineKeyboardRow(
+		tg.NewInlineKeyboardButtonData(m.Localize.Translate(btnOptionApproveTr, adminLang), fmt.Sprintf("%s|%s", cqModerationApprove, hid)),
+		tg.NewInlineKeyboardButtonData(m.Localize.Translate(btnOptionRejectTr, adminLang), fmt.Sprintf("%s|%s", cqModerationReject, hid)),
+	))
+	_, err := m.Api.Send(msg)
+	return err
+}
+
+// handleModerationCallback approves or rejects held help and marks the moderation request with the result.
+func (m *MessageHandler) handleModerationCallback(u *Update, cq, value string) error {
+	hid, err := uuid.Parse(value)
+	if err != nil {
+		return fmt.Errorf("parse help id: %w", err)
+	}
+
+	result := adminModerationApprovedTr
+	if cq == cqModerationApprove {
+		err = m.Service.ApproveHelp(u.ctx, hid)
+	} else {
+		result = adminModerationRejectedTr
+		err = m.Service.RejectHelp(u.ctx, hid)
+	}
+
+	if errors.Is(err, service.ErrNotFound) {
+		result = adminModerationDoneTr
+	} else if err != nil {
+		return err
+	}
+
+	cqm := u.CallbackQuery.Message
+	edit := tg.NewEditMessageText(cqm.Chat.ID, cqm.MessageID, fmt.Sprintf("%s\n\n%s", cqm.Text, m.Localize.Translate(result, adminLang)))
+	_, err = m.Api.Send(edit)
+	return err
+}
+
+// handleCmdAdminModeration handles moderation commands, available for admin only.
+func (m *MessageHandler) handleCmdAdminModeration(u *Update) error {
+	var (
+		cmd    = u.Message.Command()
+		phrase = strings.TrimSpace(u.Message.CommandArguments())
+		err    error
+	)
+
+	switch cmd {
+	case cmdModeration:
+		return m.sendPendingHelps(u)
+	case cmdBannedPhrases:
+		return m.sendBannedPhrases(u)
+	case cmdBanPhrase: // /ban_phrase <phrase>
+		err = m.Service.BanPhrase(u.ctx, phrase)
+	case cmdUnbanPhrase: // /unban_phrase <phrase>
+		err = m.Service.UnbanPhrase(u.ctx, phrase)
+	}
+
+	switch {
+	case err == nil:
+		return m.sendBannedPhrases(u)
+	case errors.Is(err, service.ErrNotFound):
+		_, err = m.Api.Send(tg.NewMessage(u.chatID(), m.Localize.Translate(adminBannedPhraseNotFoundTr, u.lang())))
+		return err
+	case errors.Is(err, service.ErrAlreadyExists):
+		_, err = m.Api.Send(tg.NewMessage(u.chatID(), m.Localize.Translate(adminBannedPhraseAlreadyExistsTr, u.lang())))
+		return err
+	case errors.Is(err, service.ErrInvalidInput):
+		_, err = m.Api.Send(tg.NewMessage(u.chatID(), m.Localize.Translate(adminModerationUsageTr, u.lang())))
+		return err
+	default:
+		return err
+	}
+}
+
+func (m *MessageHandler) sendPendingHelps(u *Update) error {
+	es, err := m.Service.PendingHelps(u.ctx)
+	if err != nil {
+		return err
+	}
+
+	if len(es) == 0 {
+		_, err = m.Api.Send(tg.NewMessage(u.chatID(), m.Localize.Translate(adminModerationEmptyTr, u.lang())))
+		return err
+	}
+
+	for _, e := range es {
+		err = m.sendModerationRequest(u.chatID(), e)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *MessageHandler) sendBannedPhrases(u *Update) error {
+	phrases, err := m.Service.BannedPhrases(u.ctx)
+	if err != nil {
+		return err
+	}
+
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("%s\n\n", m.Localize.Translate(adminBannedPhrasesHeaderTr, u.lang())))
+	for _, p := range phrases {
+		b.WriteString(fmt.Sprintf("%s %s\n", emojiItem, p))
+	}
+	b.WriteString(fmt.Sprintf("\n%s", m.Localize.Translate(adminModerationUsageTr, u.lang())))
+
+	_, err = m.Api.Send(tg.NewMessage(u.chatID(), b.String()))
+	return err
+}
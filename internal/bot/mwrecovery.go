@@ -14,7 +14,7 @@ type RecoverMiddleware struct {
 }
 
 func (m *RecoverMiddleware) Handle(b *tg.BotAPI, u *Update, next HandlerFunc) {
-	if u.Message == nil && u.CallbackQuery == nil {
+	if u.Message == nil && u.CallbackQuery == nil && u.InlineQuery == nil {
 		return
 	}
 
@@ -9,7 +9,10 @@ import (
 	"go.uber.org/zap"
 )
 
-const userIDCtxKey = "user_id"
+const (
+	userIDCtxKey = "user_id"
+	userCtxKey   = "user"
+)
 
 func NewUserUpsertMiddleware(ctx context.Context, l *zap.Logger, s *service.Service, api *tg.BotAPI, tr *Localizer) *UserUpsertMiddleware {
 	return &UserUpsertMiddleware{
@@ -32,9 +35,10 @@ type UserUpsertMiddleware struct {
 }
 
 func (m *UserUpsertMiddleware) Handle(b *tg.BotAPI, u *Update, next HandlerFunc) {
+	// private chat id equals user id, so users who first write from a group are still reachable
 	user, err := m.Service.NewUser(m.ctx, &service.CreateUser{
 		TgID:   u.tgUser().ID,
-		ChatID: u.chatID(),
+		ChatID: int64(u.tgUser().ID),
 		Name:   u.tgUser().UserName,
 	})
 
@@ -46,5 +50,6 @@ func (m *UserUpsertMiddleware) Handle(b *tg.BotAPI, u *Update, next HandlerFunc)
 	}
 
 	u.ctx = context.WithValue(m.ctx, userIDCtxKey, user.ID)
+	u.ctx = context.WithValue(u.ctx, userCtxKey, user)
 	next(b, u)
 }
@@ -0,0 +1,227 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	tg "github.com/go-telegram-bot-api/telegram-bot-api"
+	"github.com/rvkinc/uasocial/internal/service"
+	"go.uber.org/zap"
+)
+
+const (
+	maxDigestHelpsPerMessage = 20
+	digestSnippetLength      = 80
+	quietHoursOff            = "off"
+)
+
+// quietHoursPresets are quiet hours a user can choose from, in Kyiv time
+var quietHoursPresets = [][2]int{{22, 8}, {23, 7}, {0, 9}} // nolint:gochecknoglobals
+
+var notificationModes = []struct{ mode, tr string }{ // nolint:gochecknoglobals
+	{service.NotificationInstant, btnOptionNotificationInstantTr},
+	{service.NotificationHourly, btnOptionNotificationHourlyTr},
+	{service.NotificationDaily, btnOptionNotificationDailyTr},
+}
+
+func (m *MessageHandler) handleCmdNotifications(u *Update) error {
+	uid, err := u.userUUID()
+	if err != nil {
+		return err
+	}
+
+	np, err := m.Service.NotificationPreferences(u.ctx, uid)
+	if err != nil {
+		return err
+	}
+
+	txt, keyboard := m.notificationsMenu(np, u.lang())
+	msg := tg.NewMessage(u.chatID(), txt)
+	msg.ReplyMarkup = keyboard
+	_, err = m.Api.Send(msg)
+	return err
+}
+
+// handleNotificationsCallback applies chosen option and updates the menu in place.
+func (m *MessageHandler) handleNotificationsCallback(u *Update, cq, value string) error {
+	uid, err := u.userUUID()
+	if err != nil {
+		return err
+	}
+
+	np, err := m.Service.NotificationPreferences(u.ctx, uid)
+	if err != nil {
+		return err
+	}
+
+	switch cq {
+	case cqNotificationMode:
+		np.Mode = value
+	case cqQuietHours:
+		np.QuietHoursFrom, np.QuietHoursTo, err = parseQuietHours(value)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = m.Service.SetNotificationPreferences(u.ctx, uid, np)
+	if err != nil {
+		return err
+	}
+
+	txt, keyboard := m.notificationsMenu(np, u.lang())
+	edit := tg.NewEditMessageText(u.chatID(), u.CallbackQuery.Message.MessageID, txt)
+	edit.ReplyMarkup = &keyboard
+	_, err = m.Api.Send(edit)
+	return err
+}
+
+func (m *MessageHandler) notificationsMenu(np service.NotificationPreferences, lang string) (string, tg.InlineKeyboardMarkup) {
+	var (
+		b        strings.Builder
+		keyboard = make([][]tg.InlineKeyboardButton, 0, 2)
+		modes    = make([]tg.InlineKeyboardButton, 0, len(notificationModes))
+		quiet    = make([]tg.InlineKeyboardButton, 0, len(quietHoursPresets)+1)
+	)
+
+	for _, nm := range notificationModes {
+		text := m.Localize.Translate(nm.tr, lang)
+		if nm.mode == np.Mode {
+			b.WriteString(fmt.Sprintf("%s %s\n", m.Localize.Translate(notificationsModeTr, lang), text))
+			text = emojiCheckbox + " " + text
+		}
+		modes = append(modes, tg.NewInlineKeyboardButtonData(text, fmt.Sprintf("%s|%s", cqNotificationMode, nm.mode)))
+	}
+
+	off := m.Localize.Translate(btnOptionQuietHoursOffTr, lang)
+	if np.QuietHoursFrom == nil {
+		b.WriteString(fmt.Sprintf("%s %s\n", m.Localize.Translate(notificationsQuietHoursTr, lang), off))
+		off = emojiCheckbox + " " + off
+	} else {
+		b.WriteString(fmt.Sprintf("%s %s\n", m.Localize.Translate(notificationsQuietHoursTr, lang), formatQuietHours(*np.QuietHoursFrom, *np.QuietHoursTo)))
+	}
+	quiet = append(quiet, tg.NewInlineKeyboardButtonData(off, fmt.Sprintf("%s|%s", cqQuietHours, quietHoursOff)))
+
+	for _, p := range quietHoursPresets {
+		text := formatQuietHours(p[0], p[1])
+		if np.QuietHoursFrom != nil && *np.QuietHoursFrom == p[0] && *np.QuietHoursTo == p[1] {
+			text = emojiCheckbox + " " + text
+		}
+		quiet = append(quiet, tg.NewInlineKeyboardButtonData(text, fmt.Sprintf("%s|%d-%d", cqQuietHours, p[0], p[1])))
+	}
+
+	b.WriteString(fmt.Sprintf("\n%s", m.Localize.Translate(notificationsHintTr, lang)))
+	keyboard = append(keyboard, modes, quiet)
+	return b.String(), tg.InlineKeyboardMarkup{InlineKeyboard: keyboard}
+}
+
+func formatQuietHours(from, to int) string { return fmt.Sprintf("%02d:00–%02d:00", from, to) }
+
+// parseQuietHours parses "22-8" quiet hours callback value.
+func parseQuietHours(s string) (from, to *int, err error) {
+	if s == quietHoursOff {
+		return nil, nil, nil
+	}
+
+	hours := strings.Split(s, "-")
+	if len(hours) != 2 {
+		return nil, nil, fmt.Errorf("invalid quiet hours: %s", s)
+	}
+
+	f, err := strconv.Atoi(hours[0])
+	if err != nil {
+		return nil, nil, err
+	}
+
+	t, err := strconv.Atoi(hours[1])
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &f, &t, nil
+}
+
+func (m *MessageHandler) listenDigests(ctx context.Context) {
+	for {
+		select {
+		case d := <-m.Service.Digests():
+			sendErr := m.sendDigest(d)
+			if sendErr != nil {
+				m.L.Error("send digest", zap.Error(sendErr))
+			}
+
+			// failed digests are sent again later
+			err := m.Service.DigestSent(ctx, d, sendErr)
+			if err != nil {
+				m.L.Error("complete digest", zap.Error(err))
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// sendDigest sends helps grouped by subscription with a view button per help.
+// Large digests are split into several messages.
+func (m *MessageHandler) sendDigest(d service.Digest) error {
+	var (
+		b       strings.Builder
+		buttons []tg.InlineKeyboardButton
+		n       int
+	)
+
+	send := func() error {
+		if len(buttons) == 0 {
+			return nil
+		}
+
+		keyboard := make([][]tg.InlineKeyboardButton, 0, len(buttons)/5+1)
+		for i := 0; i < len(buttons); i += 5 {
+			end := i + 5
+			if end > len(buttons) {
+				end = len(buttons)
+			}
+			keyboard = append(keyboard, buttons[i:end])
+		}
+
+		msg := tg.NewMessage(d.ChatID, fmt.Sprintf("%s\n%s", m.Localize.Translate(digestHeaderTr, d.Language), b.String()))
+		msg.ReplyMarkup = tg.InlineKeyboardMarkup{InlineKeyboard: keyboard}
+		_, err := m.Api.Send(msg)
+
+		b.Reset()
+		buttons = nil
+		return err
+	}
+
+	for _, s := range d.Subscriptions {
+		for i, h := range s.Helps {
+			if i == 0 || b.Len() == 0 {
+				b.WriteString(fmt.Sprintf("\n%s %s\n", emojiLocation, s.Locality))
+				if s.Keywords != "" {
+					b.WriteString(fmt.Sprintf("%s %s\n", emojiKeywords, s.Keywords))
+				}
+				for _, c := range s.Categories {
+					b.WriteString(fmt.Sprintf("%s %s\n", emojiItem, c))
+				}
+			}
+
+			n++
+			b.WriteString(fmt.Sprintf("%d. %s\n", n, snippet(h.Description, digestSnippetLength)))
+			buttons = append(buttons, tg.NewInlineKeyboardButtonData(
+				fmt.Sprintf(m.Localize.Translate(btnOptionViewHelpTr, d.Language), n),
+				fmt.Sprintf("%s|%s", cqViewHelp, h.ID),
+			))
+
+			if len(buttons) == maxDigestHelpsPerMessage {
+				err := send()
+				if err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return send()
+}
@@ -0,0 +1,180 @@
+package bot
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	tg "github.com/go-telegram-bot-api/telegram-bot-api"
+	"github.com/google/uuid"
+	"github.com/rvkinc/uasocial/internal/service"
+)
+
+const (
+	emojiOrganisation = "🏢"
+	emojiVerified     = "☑️"
+)
+
+// organisationText renders the organisation a help is posted on behalf of, its profile is shown for a single help.
+func (m *MessageHandler) organisationText(o *service.HelpOrganisation, lang string) string {
+	if o == nil {
+		return ""
+	}
+
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("%s %s", emojiOrganisation, o.Name))
+	if o.Verified {
+		b.WriteString(fmt.Sprintf(" %s %s", emojiVerified, m.Localize.Translate(organisationVerifiedTr, lang)))
+	}
+	b.WriteString("\n")
+	if o.Description != "" {
+		b.WriteString(fmt.Sprintf("%s\n", o.Description))
+	}
+	if o.Contact != "" {
+		b.WriteString(fmt.Sprintf("%s %s\n", emojiPhone, o.Contact))
+	}
+	return b.String()
+}
+
+func organisationButtonText(o service.Organisation, chosen *service.Organisation) string {
+	if chosen != nil && chosen.ID == o.ID {
+		return emojiCheckbox + " " + o.Name
+	}
+	return o.Name
+}
+
+func (m *MessageHandler) sendVolunteerOrganisationRequest(u *Update) error {
+	d := m.dialogs.get(u.dialogKey())
+
+	keyboard := make([][]tg.KeyboardButton, 0, len(d.volunteer.organisations)+2)
+	for _, o := range d.volunteer.organisations {
+		keyboard = append(keyboard, []tg.KeyboardButton{{Text: organisationButtonText(o, d.volunteer.organisation)}})
+	}
+	keyboard = append(keyboard,
+		[]tg.KeyboardButton{{Text: m.Localize.Translate(btnOptionPersonalHelpTr, u.lang())}},
+		[]tg.KeyboardButton{{Text: m.Localize.Translate(btnOptionBackTr, u.lang())}},
+	)
+
+	msg := tg.NewMessage(u.chatID(), m.Localize.Translate(volunteerOrganisationRequestTr, u.lang()))
+	msg.ReplyMarkup = tg.ReplyKeyboardMarkup{Keyboard: keyboard, ResizeKeyboard: true}
+	_, err := m.Api.Send(msg)
+	if err != nil {
+		return err
+	}
+
+	d.next = m.handleVolunteerOrganisationReply
+	return nil
+}
+
+// handleVolunteerOrganisationReply chooses the organisation the help is posted on behalf of and goes back to details menu.
+func (m *MessageHandler) handleVolunteerOrganisationReply(u *Update) error {
+	d := m.dialogs.get(u.dialogKey())
+
+	switch u.Message.Text {
+	case m.Localize.Translate(btnOptionBackTr, u.lang()):
+		return m.sendVolunteerDetailsMenu(u)
+	case m.Localize.Translate(btnOptionPersonalHelpTr, u.lang()):
+		d.volunteer.organisation = nil
+		return m.sendVolunteerDetailsMenu(u)
+	}
+
+	for _, o := range d.volunteer.organisations {
+		if u.Message.Text == organisationButtonText(o, d.volunteer.organisation) {
+			o := o
+			d.volunteer.organisation = &o
+			return m.sendVolunteerDetailsMenu(u)
+		}
+	}
+
+	_, err := m.Api.Send(tg.NewMessage(u.chatID(), m.Localize.Translate(errorChooseOptionTr, u.lang())))
+	return err
+}
+
+// handleCmdAdminOrganisation handles organisation management commands, available for admin only.
+func (m *MessageHandler) handleCmdAdminOrganisation(u *Update) error {
+	var (
+		cmd  = u.Message.Command()
+		args = strings.TrimSpace(u.Message.CommandArguments())
+		err  error
+	)
+
+	switch cmd {
+	case cmdOrganisations:
+		return m.sendOrganisationsList(u)
+	case cmdOrganisationAdd: // /org_add name | description | contact
+		fields := strings.Split(args, "|")
+		if len(fields) != 3 {
+			err = errInvalidArguments
+			break
+		}
+		_, err = m.Service.NewOrganisation(u.ctx, fields[0], fields[1], fields[2])
+	case cmdOrganisationVerify, cmdOrganisationUnverify: // /org_verify <id>
+		var ids []uuid.UUID
+		ids, err = parseUUIDs(strings.Fields(args))
+		if err == nil && len(ids) != 1 {
+			err = errInvalidArguments
+		}
+		if err == nil {
+			err = m.Service.VerifyOrganisation(u.ctx, ids[0], cmd == cmdOrganisationVerify)
+		}
+	case cmdOrganisationMemberAdd, cmdOrganisationMemberRemove: // /org_member_add <id> <telegram id>
+		var (
+			fields = strings.Fields(args)
+			ids    []uuid.UUID
+			tgID   int
+		)
+		if len(fields) != 2 {
+			err = errInvalidArguments
+			break
+		}
+		ids, err = parseUUIDs(fields[:1])
+		if err == nil {
+			tgID, err = strconv.Atoi(fields[1])
+		}
+		if err == nil && cmd == cmdOrganisationMemberAdd {
+			err = m.Service.AddOrganisationMember(u.ctx, ids[0], tgID)
+		} else if err == nil {
+			err = m.Service.RemoveOrganisationMember(u.ctx, ids[0], tgID)
+		}
+	}
+
+	switch {
+	case err == nil:
+		return m.sendOrganisationsList(u)
+	case errors.Is(err, service.ErrNotFound):
+		_, err = m.Api.Send(tg.NewMessage(u.chatID(), m.Localize.Translate(adminOrganisationNotFoundTr, u.lang())))
+		return err
+	case errors.Is(err, service.ErrAlreadyExists):
+		_, err = m.Api.Send(tg.NewMessage(u.chatID(), m.Localize.Translate(adminOrganisationAlreadyExistsTr, u.lang())))
+		return err
+	case isUsageError(err):
+		_, err = m.Api.Send(tg.NewMessage(u.chatID(), m.Localize.Translate(adminOrganisationUsageTr, u.lang())))
+		return err
+	default:
+		return err
+	}
+}
+
+func (m *MessageHandler) sendOrganisationsList(u *Update) error {
+	orgs, err := m.Service.Organisations(u.ctx)
+	if err != nil {
+		return err
+	}
+
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("%s\n\n", m.Localize.Translate(adminOrganisationListHeaderTr, u.lang())))
+	for _, o := range orgs {
+		b.WriteString(fmt.Sprintf("%s %s", emojiOrganisation, o.Name))
+		if o.Verified {
+			b.WriteString(" " + emojiVerified)
+		}
+		b.WriteString(fmt.Sprintf(" (%d)\n%s\n", o.Members, o.ID))
+	}
+	b.WriteString(fmt.Sprintf("\n%s", m.Localize.Translate(adminOrganisationUsageTr, u.lang())))
+
+	msg := tg.NewMessage(u.chatID(), b.String())
+	msg.ReplyMarkup = tg.ReplyKeyboardHide{HideKeyboard: true}
+	_, err = m.Api.Send(msg)
+	return err
+}
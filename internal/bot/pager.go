@@ -0,0 +1,329 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	tg "github.com/go-telegram-bot-api/telegram-bot-api"
+	"github.com/rvkinc/uasocial/internal/service"
+)
+
+const (
+	helpsPerPage          = 5
+	pageDescriptionLength = 400
+	pagerTTL              = 24 * time.Hour
+
+	pagePrev = "prev"
+	pageNext = "next"
+
+	filterDay      = "24h"
+	filterWeek     = "7d"
+	filterPhone    = "phone"
+	filterNow      = "now"
+	filterRoom     = "room"
+	filterLang     = "lang"
+	filterVerified = "verified"
+
+	emojiPrev = "◀️"
+	emojiNext = "▶️"
+)
+
+type (
+	// helpPageFunc fetches a page of helps after the cursor, nil cursor fetches the first page.
+	helpPageFunc func(ctx context.Context, after *service.Cursor) (service.HelpPage, error)
+
+	// helpPager keeps position in a help list rendered as a single message.
+	helpPager struct {
+		mu    *sync.Mutex
+		fetch helpPageFunc
+		own   bool               // user's own helps, rendered with share links and delete buttons
+		query *service.HelpQuery // search query, its sorting and filters are exposed as buttons
+
+		highlight bool // headlines of text search are rendered instead of descriptions
+
+		cursors   []*service.Cursor // cursors of visited pages, the last one is the current page
+		next      *service.Cursor
+		createdAt time.Time
+	}
+
+	// pagerKey identifies the message a help list is rendered in.
+	pagerKey struct {
+		chatID    int64
+		messageID int
+	}
+
+	pagers struct {
+		mu    *sync.Mutex
+		state map[pagerKey]*helpPager
+	}
+)
+
+func newHelpPager(fetch helpPageFunc, own bool) *helpPager {
+	return &helpPager{mu: &sync.Mutex{}, fetch: fetch, own: own, createdAt: time.Now()}
+}
+
+// newSearchPager returns pager of search results, the query is changed by sorting and filter buttons.
+func (m *MessageHandler) newSearchPager(q service.HelpQuery) *helpPager {
+	p := newHelpPager(func(ctx context.Context, after *service.Cursor) (service.HelpPage, error) {
+		return m.Service.HelpsByCategoryLocation(ctx, q, after, helpsPerPage)
+	}, false)
+	p.query = &q
+	return p
+}
+
+// set stores pager and drops pagers older than pagerTTL.
+func (p *pagers) set(pager *helpPager, key pagerKey) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for k, v := range p.state {
+		if time.Since(v.createdAt) > pagerTTL {
+			delete(p.state, k)
+		}
+	}
+	p.state[key] = pager
+}
+
+func (p *pagers) get(key pagerKey) *helpPager {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.state[key]
+}
+
+// sendHelpPager sends the first page of helps, false is returned if there are no helps.
+func (m *MessageHandler) sendHelpPager(u *Update, p *helpPager) (bool, error) {
+	page, err := p.fetch(u.ctx, nil)
+	if err != nil {
+		return false, err
+	}
+
+	if len(page.Helps) == 0 {
+		return false, nil
+	}
+
+	p.cursors = []*service.Cursor{nil}
+	p.next = page.Next
+
+	msg := tg.NewMessage(u.chatID(), m.helpPageText(p, page.Helps, u.lang()))
+	msg.ReplyMarkup = m.helpPageKeyboard(p, page.Helps, u.lang())
+	if p.highlight {
+		msg.ParseMode = tg.ModeHTML
+	}
+	sent, err := m.Api.Send(msg)
+	if err != nil {
+		return false, err
+	}
+
+	m.pagers.set(p, pagerKey{chatID: u.chatID(), messageID: sent.MessageID})
+	return true, nil
+}
+
+// handleHelpPageCallback moves pager one page back or forth and edits its message in place.
+func (m *MessageHandler) handleHelpPageCallback(u *Update, value string) error {
+	key := pagerKey{chatID: u.chatID(), messageID: u.CallbackQuery.Message.MessageID}
+	p := m.pagers.get(key)
+	if p == nil {
+		return m.sendPageExpired(u)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var after *service.Cursor
+	switch {
+	case value == pageNext && p.next != nil:
+		after = p.next
+	case value == pagePrev && len(p.cursors) > 1:
+		after = p.cursors[len(p.cursors)-2]
+	default:
+		return nil
+	}
+
+	page, err := p.fetch(u.ctx, after)
+	if err != nil {
+		return err
+	}
+
+	if value == pageNext {
+		p.cursors = append(p.cursors, after)
+	} else {
+		p.cursors = p.cursors[:len(p.cursors)-1]
+	}
+	p.next = page.Next
+
+	return m.editHelpPage(u, p, page.Helps)
+}
+
+// handleHelpQueryCallback changes sorting or filters of search results and shows their first page.
+func (m *MessageHandler) handleHelpQueryCallback(u *Update, cq, value string) error {
+	p := m.pagers.get(pagerKey{chatID: u.chatID(), messageID: u.CallbackQuery.Message.MessageID})
+	if p == nil || p.query == nil {
+		return m.sendPageExpired(u)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	switch {
+	case cq == cqHelpSort && service.HelpSort(value).Valid():
+		p.query.Sort = service.HelpSort(value)
+	case value == filterDay:
+		p.query.PostedWithin = togglePostedWithin(p.query.PostedWithin, 24*time.Hour)
+	case value == filterWeek:
+		p.query.PostedWithin = togglePostedWithin(p.query.PostedWithin, 7*24*time.Hour)
+	case value == filterPhone:
+		p.query.HasPhone = !p.query.HasPhone
+	case value == filterNow:
+		p.query.AvailableNow = !p.query.AvailableNow
+	case value == filterRoom:
+		p.query.HasCapacity = !p.query.HasCapacity
+	case value == filterLang && p.query.Language == "":
+		p.query.Language = u.lang()
+	case value == filterLang:
+		p.query.Language = ""
+	case value == filterVerified:
+		p.query.VerifiedOnly = !p.query.VerifiedOnly
+	default:
+		return nil
+	}
+
+	page, err := p.fetch(u.ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	p.cursors = []*service.Cursor{nil}
+	p.next = page.Next
+
+	return m.editHelpPage(u, p, page.Helps)
+}
+
+func togglePostedWithin(current, d time.Duration) time.Duration {
+	if current == d {
+		return 0
+	}
+	return d
+}
+
+func (m *MessageHandler) sendPageExpired(u *Update) error {
+	_, err := m.Api.Send(tg.NewMessage(u.chatID(), m.Localize.Translate(errorPageExpiredTr, u.lang())))
+	return err
+}
+
+// editHelpPage renders helps in the message the callback came from.
+func (m *MessageHandler) editHelpPage(u *Update, p *helpPager, helps []service.UserHelp) error {
+	msg := u.CallbackQuery.Message
+	edit := tg.NewEditMessageText(msg.Chat.ID, msg.MessageID, m.helpPageText(p, helps, u.lang()))
+	keyboard := m.helpPageKeyboard(p, helps, u.lang())
+	edit.ReplyMarkup = &keyboard
+	if p.highlight {
+		edit.ParseMode = tg.ModeHTML
+	}
+	_, err := m.Api.Send(edit)
+	return err
+}
+
+func (m *MessageHandler) helpPageText(p *helpPager, helps []service.UserHelp, lang string) string {
+	if len(helps) == 0 {
+		return m.Localize.Translate(seekerHelpsEmptyTr, lang)
+	}
+
+	var b strings.Builder
+	for i, h := range helps {
+		h.Description = truncate(h.Description, pageDescriptionLength)
+		if p.highlight && h.Headline != "" {
+			h.Description = h.Headline
+		}
+		b.WriteString(fmt.Sprintf("%d. %s", i+1, m.helpText(h, lang)))
+		if p.own {
+			b.WriteString(fmt.Sprintf("%s %s\n", m.Localize.Translate(helpLinkTr, lang), m.Service.HelpLink(h.ID)))
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString(fmt.Sprintf(m.Localize.Translate(helpsPageTr, lang), len(p.cursors)))
+
+	if p.highlight {
+		return highlight(b.String())
+	}
+	return b.String()
+}
+
+// helpPageKeyboard returns buttons to view or delete helps of the page and to navigate between pages.
+func (m *MessageHandler) helpPageKeyboard(p *helpPager, helps []service.UserHelp, lang string) tg.InlineKeyboardMarkup {
+	keyboard := make([][]tg.InlineKeyboardButton, 0, 3)
+
+	view := make([]tg.InlineKeyboardButton, 0, len(helps))
+	for i, h := range helps {
+		view = append(view, tg.NewInlineKeyboardButtonData(
+			fmt.Sprintf(m.Localize.Translate(btnOptionViewHelpTr, lang), i+1),
+			fmt.Sprintf("%s|%s", cqViewHelp, h.ID),
+		))
+	}
+	if len(view) > 0 {
+		keyboard = append(keyboard, view)
+	}
+
+	if p.own {
+		remove := make([]tg.InlineKeyboardButton, 0, len(helps))
+		for i, h := range helps {
+			remove = append(remove, tg.NewInlineKeyboardButtonData(
+				fmt.Sprintf(m.Localize.Translate(btnOptionDeleteHelpTr, lang), i+1),
+				fmt.Sprintf("%s|%s", cmdMyHelp, h.ID),
+			))
+		}
+		if len(remove) > 0 {
+			keyboard = append(keyboard, remove)
+		}
+	}
+
+	nav := make([]tg.InlineKeyboardButton, 0, 2)
+	if len(p.cursors) > 1 {
+		nav = append(nav, tg.NewInlineKeyboardButtonData(emojiPrev, fmt.Sprintf("%s|%s", cqHelpPage, pagePrev)))
+	}
+	if p.next != nil {
+		nav = append(nav, tg.NewInlineKeyboardButtonData(emojiNext, fmt.Sprintf("%s|%s", cqHelpPage, pageNext)))
+	}
+	if len(nav) > 0 {
+		keyboard = append(keyboard, nav)
+	}
+
+	if p.query != nil {
+		keyboard = append(keyboard, m.helpQueryKeyboard(p.query, lang)...)
+	}
+
+	return tg.InlineKeyboardMarkup{InlineKeyboard: keyboard}
+}
+
+// helpQueryKeyboard returns sorting and filter buttons, the chosen ones are checked.
+func (m *MessageHandler) helpQueryKeyboard(q *service.HelpQuery, lang string) [][]tg.InlineKeyboardButton {
+	button := func(tr string, checked bool, cq, value string) tg.InlineKeyboardButton {
+		text := m.Localize.Translate(tr, lang)
+		if checked {
+			text = emojiCheckbox + " " + text
+		}
+		return tg.NewInlineKeyboardButtonData(text, fmt.Sprintf("%s|%s", cq, value))
+	}
+
+	return [][]tg.InlineKeyboardButton{
+		{
+			button(btnOptionSortNewestTr, q.Sort == service.SortNewest, cqHelpSort, string(service.SortNewest)),
+			button(btnOptionSortNearestTr, q.Sort == service.SortNearest, cqHelpSort, string(service.SortNearest)),
+			button(btnOptionSortConfirmedTr, q.Sort == service.SortConfirmed, cqHelpSort, string(service.SortConfirmed)),
+		},
+		{
+			button(btnOptionPostedDayTr, q.PostedWithin == 24*time.Hour, cqHelpFilter, filterDay),
+			button(btnOptionPostedWeekTr, q.PostedWithin == 7*24*time.Hour, cqHelpFilter, filterWeek),
+			button(btnOptionHasPhoneTr, q.HasPhone, cqHelpFilter, filterPhone),
+		},
+		{
+			button(btnOptionAvailableNowTr, q.AvailableNow, cqHelpFilter, filterNow),
+			button(btnOptionHasCapacityTr, q.HasCapacity, cqHelpFilter, filterRoom),
+			button(btnOptionMyLanguageTr, q.Language != "", cqHelpFilter, filterLang),
+		},
+		{
+			button(btnOptionVerifiedOnlyTr, q.VerifiedOnly, cqHelpFilter, filterVerified),
+		},
+	}
+}
@@ -0,0 +1,48 @@
+package bot
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rvkinc/uasocial/internal/service"
+)
+
+// helpText renders help the same way in search results, notifications and user's helps list.
+func (m *MessageHandler) helpText(h service.UserHelp, lang string) string {
+	var b strings.Builder
+	b.WriteString(m.organisationText(h.Organisation, lang))
+	b.WriteString(fmt.Sprintf("%s %s\n", emojiLocation, h.Locality))
+	b.WriteString(fmt.Sprintf("%s %s\n", emojiTime, m.Localize.FormatDateTime(h.CreatedAt, lang)))
+	for _, c := range h.Categories {
+		b.WriteString(fmt.Sprintf("%s %s\n", emojiItem, c))
+	}
+	b.WriteString(m.detailsText(h.Details, lang))
+	if h.AttachmentsCount > 0 {
+		b.WriteString(fmt.Sprintf("%s %d\n", emojiAttached, h.AttachmentsCount))
+	}
+	b.WriteString(fmt.Sprintf("%s\n", h.Description))
+	b.WriteString(reputationText(h.Reputation))
+	if h.Pending {
+		b.WriteString(fmt.Sprintf("%s\n", m.Localize.Translate(helpPendingTr, lang)))
+	}
+	return b.String()
+}
+
+// snippet returns the first line of s cut to n runes.
+func snippet(s string, n int) string {
+	if i := strings.IndexByte(s, '\n'); i >= 0 {
+		s = s[:i]
+	}
+
+	return truncate(s, n)
+}
+
+// truncate cuts s to n runes.
+func truncate(s string, n int) string {
+	r := []rune(s)
+	if len(r) <= n {
+		return s
+	}
+
+	return strings.TrimSpace(string(r[:n])) + "…"
+}
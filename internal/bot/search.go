@@ -0,0 +1,110 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"strings"
+
+	tg "github.com/go-telegram-bot-api/telegram-bot-api"
+	"github.com/rvkinc/uasocial/internal/service"
+)
+
+// handleCmdSearch searches helps by "/search <text>", the text is requested when missing.
+func (m *MessageHandler) handleCmdSearch(u *Update) error {
+	m.dialogs.set(&dialog{role: roleSeeker, seeker: new(seeker), next: m.handleSearchTextReply}, u.dialogKey())
+
+	text := strings.TrimSpace(u.Message.CommandArguments())
+	if text != "" {
+		return m.searchText(u, text)
+	}
+
+	return m.sendSearchTextRequest(u)
+}
+
+// handleSearchTextReply searches by text, the dialog goes on so the query can be refined.
+// Subscribe button creates keyword subscription on the last searched text.
+func (m *MessageHandler) handleSearchTextReply(u *Update) error {
+	d := m.dialogs.get(u.dialogKey())
+	if u.Message.Text != m.Localize.Translate(btnOptionSubscribeTr, u.lang()) || d.seeker.keywords == "" {
+		return m.searchText(u, u.Message.Text)
+	}
+
+	reached, err := m.subscriptionsLimitReached(u)
+	if err != nil || reached {
+		return err
+	}
+
+	if d.seeker.locality == nil {
+		return m.createSeekerSubscription(u, 0)
+	}
+
+	return m.sendSubscriptionScopeRequest(u)
+}
+
+// searchText searches the whole country or, in the seeker flow, chosen categories in the oblast of chosen locality.
+func (m *MessageHandler) searchText(u *Update, text string) error {
+	d := m.dialogs.get(u.dialogKey())
+	d.seeker.keywords = text
+
+	q := service.TextSearch{Text: text, CategoryIDs: d.seeker.categoryIDs()}
+	if d.seeker.locality != nil {
+		ancestors, err := m.Service.LocalityAncestors(u.ctx, d.seeker.locality.ID)
+		if err != nil {
+			return err
+		}
+
+		for _, a := range ancestors {
+			if a.Type == service.LocalityTypeState {
+				q.RegionID = a.ID
+			}
+		}
+	}
+
+	return m.sendTextSearch(u, q)
+}
+
+func (m *MessageHandler) sendSearchTextRequest(u *Update) error {
+	msg := tg.NewMessage(u.chatID(), m.Localize.Translate(searchTextRequestTr, u.lang()))
+	msg.ReplyMarkup = tg.ReplyKeyboardMarkup{
+		Keyboard:       [][]tg.KeyboardButton{{{Text: m.Localize.Translate(btnOptionCancelTr, u.lang())}}},
+		ResizeKeyboard: true,
+	}
+	_, err := m.Api.Send(msg)
+	return err
+}
+
+// sendTextSearch sends search results followed by a proposal to subscribe on the searched words.
+func (m *MessageHandler) sendTextSearch(u *Update, q service.TextSearch) error {
+	p := newHelpPager(func(ctx context.Context, after *service.Cursor) (service.HelpPage, error) {
+		return m.Service.SearchHelps(ctx, q, after, helpsPerPage)
+	}, false)
+	p.highlight = true
+
+	found, err := m.sendHelpPager(u, p)
+	if err != nil {
+		return err
+	}
+
+	text := m.Localize.Translate(searchSubscriptionProposalTr, u.lang())
+	if !found {
+		text = fmt.Sprintf("%s\n\n%s", m.Localize.Translate(searchEmptyTr, u.lang()), text)
+	}
+
+	msg := tg.NewMessage(u.chatID(), text)
+	msg.ReplyMarkup = tg.ReplyKeyboardMarkup{
+		Keyboard: [][]tg.KeyboardButton{{
+			{Text: m.Localize.Translate(btnOptionCancelTr, u.lang())},
+			{Text: m.Localize.Translate(btnOptionSubscribeTr, u.lang())},
+		}},
+		ResizeKeyboard: true,
+	}
+
+	_, err = m.Api.Send(msg)
+	return err
+}
+
+// highlight escapes text for HTML parse mode and makes words matched by text search bold.
+func highlight(text string) string {
+	return strings.NewReplacer(service.HighlightStart, "<b>", service.HighlightEnd, "</b>").Replace(html.EscapeString(text))
+}
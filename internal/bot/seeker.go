@@ -12,14 +12,27 @@ import (
 	"github.com/rvkinc/uasocial/internal/service"
 )
 
-const (
-	maxSubscriptionsPerUser = 5
-)
-
 type seeker struct {
-	category   *service.CategoryTranslated
+	categories *categoryPicker
 	localities service.Localities
 	locality   *service.Locality
+	scopes     service.Localities // locality and its ancestors to subscribe on
+	chatID     *int64             // group chat the subscription is created for
+	keywords   string             // last searched text, subscribed on as keywords
+}
+
+// categoryIDs returns chosen categories, text search may go without them.
+func (s *seeker) categoryIDs() []uuid.UUID {
+	if s.categories == nil {
+		return nil
+	}
+
+	selected := s.categories.selected()
+	cids := make([]uuid.UUID, 0, len(selected))
+	for _, c := range selected {
+		cids = append(cids, c.ID)
+	}
+	return cids
 }
 
 func (m *MessageHandler) handleCmdMySubscriptions(u *Update) error {
@@ -35,7 +48,7 @@ func (m *MessageHandler) handleCmdMySubscriptions(u *Update) error {
 	}
 
 	if len(subs) == 0 {
-		msg := tg.NewMessage(u.chatID(), fmt.Sprintf("%s\n\n%s", m.Localize.Translate(errorNoSubscriptionsTr, UALang), m.Localize.Translate(navigationHintTr, UALang)))
+		msg := tg.NewMessage(u.chatID(), fmt.Sprintf("%s\n\n%s", m.Localize.Translate(errorNoSubscriptionsTr, u.lang()), m.Localize.Translate(navigationHintTr, u.lang())))
 		msg.ReplyMarkup = tg.ReplyKeyboardHide{HideKeyboard: true}
 		_, err = m.Api.Send(msg)
 		return err
@@ -44,9 +57,14 @@ func (m *MessageHandler) handleCmdMySubscriptions(u *Update) error {
 	for _, s := range subs {
 		var b strings.Builder
 
-		b.WriteString(fmt.Sprintf("%s %s\n", emojiTime, m.Localize.FormatDateTime(s.CreatedAt, UALang)))
+		b.WriteString(fmt.Sprintf("%s %s\n", emojiTime, m.Localize.FormatDateTime(s.CreatedAt, u.lang())))
 		b.WriteString(fmt.Sprintf("%s %s\n", emojiLocation, s.Locality))
-		b.WriteString(fmt.Sprintf("%s %s\n", emojiItem, s.Category))
+		if s.Keywords != "" {
+			b.WriteString(fmt.Sprintf("%s %s\n", emojiKeywords, s.Keywords))
+		}
+		for _, c := range s.Categories {
+			b.WriteString(fmt.Sprintf("%s %s\n", emojiItem, c))
+		}
 
 		var (
 			deleteQueryString        = fmt.Sprintf("%s|%s", cmdMySubscriptions, s.ID.String())
@@ -58,13 +76,13 @@ func (m *MessageHandler) handleCmdMySubscriptions(u *Update) error {
 		msg.ReplyMarkup = tg.InlineKeyboardMarkup{InlineKeyboard: [][]tg.InlineKeyboardButton{
 			{
 				{
-					Text:         m.Localize.Translate(btnOptionDeleteTr, UALang),
+					Text:         m.Localize.Translate(btnOptionDeleteTr, u.lang()),
 					CallbackData: &deleteQueryString,
 				},
 			},
 			{
 				{
-					Text:         m.Localize.Translate(btnOptionHelpsBySubscription, UALang),
+					Text:         m.Localize.Translate(btnOptionHelpsBySubscription, u.lang()),
 					CallbackData: &subscriptionsQueryString,
 				},
 			},
@@ -80,43 +98,19 @@ func (m *MessageHandler) handleCmdMySubscriptions(u *Update) error {
 }
 
 func (m *MessageHandler) handleSeekerUserRoleReply(u *Update) error {
-	uid, err := u.userUUID()
-	if err != nil {
-		return err
-	}
-
-	count, err := m.Service.SubscriptionsCountByUser(u.ctx, uid)
-	if err != nil {
+	reached, err := m.subscriptionsLimitReached(u)
+	if err != nil || reached {
 		return err
 	}
 
-	if count >= maxSubscriptionsPerUser {
-		m.dialogs.delete(u.chatID())
-		msg := tg.NewMessage(u.chatID(), fmt.Sprintf(m.Localize.Translate(errorSubscriptionsLimitExceededTr, UALang), maxSubscriptionsPerUser))
-		msg.ReplyMarkup = tg.ReplyKeyboardHide{HideKeyboard: true}
-		_, err = m.Api.Send(msg)
-		return err
-	}
-
-	d := m.dialogs.get(u.chatID())
+	d := m.dialogs.get(u.dialogKey())
 	d.role = roleSeeker
 	d.seeker = new(seeker)
-	msg := tg.NewMessage(u.chatID(), m.Localize.Translate(seekerCategoryRequestTr, UALang))
-
-	keyboardButtons := make([][]tg.KeyboardButton, 0)
-
-	for _, category := range m.categories {
-		if len(keyboardButtons) == 0 || len(keyboardButtons[len(keyboardButtons)-1]) == 2 {
-			keyboardButtons = append(keyboardButtons, []tg.KeyboardButton{{Text: category.Name}})
-			continue
-		}
-		keyboardButtons[len(keyboardButtons)-1] = append(keyboardButtons[len(keyboardButtons)-1], tg.KeyboardButton{Text: category.Name})
-	}
-
-	keyboardButtons = append(keyboardButtons, []tg.KeyboardButton{{Text: m.Localize.Translate(btnOptionCancelTr, UALang)}})
+	d.seeker.categories = m.newCategoryPicker(u.lang())
 
+	msg := tg.NewMessage(u.chatID(), m.Localize.Translate(seekerCategoryRequestTr, u.lang()))
 	msg.ReplyMarkup = tg.ReplyKeyboardMarkup{
-		Keyboard:       keyboardButtons,
+		Keyboard:       d.seeker.categories.layout(m.Localize.Translate(btnOptionCancelTr, u.lang()), ""),
 		ResizeKeyboard: true,
 	}
 
@@ -125,51 +119,69 @@ func (m *MessageHandler) handleSeekerUserRoleReply(u *Update) error {
 		return err
 	}
 
-	m.dialogs.get(u.chatID()).next = m.handleSeekerCategoryBtnReply
+	m.dialogs.get(u.dialogKey()).next = m.handleSeekerCategoryBtnReply
 
 	return nil
 }
 
-func (m *MessageHandler) handleSeekerCategoryBtnReply(u *Update) error {
-	d := m.dialogs.get(u.chatID())
+// subscriptionsLimitReached ends the dialog and notifies user if no more subscriptions are allowed.
+func (m *MessageHandler) subscriptionsLimitReached(u *Update) (bool, error) {
+	uid, err := u.userUUID()
+	if err != nil {
+		return false, err
+	}
 
-	for i := range m.categories {
-		if m.categories[i].Name == u.Message.Text {
-			d.seeker.category = &m.categories[i]
-		}
+	count, err := m.Service.SubscriptionsCountByUser(u.ctx, uid)
+	if err != nil {
+		return false, err
 	}
 
-	if d.seeker.category == nil {
-		_, err := m.Api.Send(tg.NewMessage(u.chatID(), m.Localize.Translate(errorChooseOptionTr, UALang)))
-		return err
+	limit := m.Service.UserLimits(u.tier()).SubscriptionsPerUser
+	if count < limit {
+		return false, nil
 	}
 
-	msg := tg.NewMessage(u.chatID(), m.Localize.Translate(userLocalityRequestTr, UALang))
-	msg.ReplyMarkup = tg.ReplyKeyboardMarkup{
-		Keyboard: [][]tg.KeyboardButton{{
-			{Text: m.Localize.Translate(btnOptionCancelTr, UALang)},
-		}},
-		ResizeKeyboard: true,
+	m.dialogs.delete(u.dialogKey())
+	msg := tg.NewMessage(u.chatID(), fmt.Sprintf(m.Localize.Translate(errorSubscriptionsLimitExceededTr, u.lang()), limit))
+	msg.ReplyMarkup = tg.ReplyKeyboardHide{HideKeyboard: true}
+	_, err = m.Api.Send(msg)
+	return true, err
+}
+
+func (m *MessageHandler) handleSeekerCategoryBtnReply(u *Update) error {
+	d := m.dialogs.get(u.dialogKey())
+
+	if u.Message.Text != m.Localize.Translate(btnOptionNextTr, u.lang()) || len(d.seeker.categories.selected()) == 0 {
+		return m.handleCategoryPickerReply(u, d.seeker.categories, seekerCategoryRequestTr)
 	}
 
-	_, err := m.Api.Send(msg)
+	err := m.sendLocalityRequest(u)
 	if err != nil {
 		return err
 	}
 
-	m.dialogs.get(u.chatID()).next = m.handleSeekerLocalityTextReply
+	m.dialogs.get(u.dialogKey()).next = m.handleSeekerLocalityTextReply
 
 	return nil
 }
 
 func (m *MessageHandler) handleSeekerLocalityTextReply(u *Update) error {
-	localities, err := m.Service.AutocompleteLocality(u.ctx, strings.Title(strings.ToLower(u.Message.Text)))
+	l, ok, err := m.shortcutLocality(u)
+	if err != nil {
+		return err
+	}
+
+	if ok {
+		return m.handleSeekerLocality(u, l)
+	}
+
+	localities, err := m.Service.AutocompleteLocality(u.ctx, u.Message.Text)
 	if err != nil {
 		return err
 	}
 
 	if len(localities) == 0 {
-		msg := tg.NewMessage(u.chatID(), m.Localize.Translate(errorPleaseTryAgainTr, UALang))
+		msg := tg.NewMessage(u.chatID(), m.Localize.Translate(errorPleaseTryAgainTr, u.lang()))
 		_, err = m.Api.Send(msg)
 		return err
 	}
@@ -177,16 +189,15 @@ func (m *MessageHandler) handleSeekerLocalityTextReply(u *Update) error {
 	keyboardButtons := make([][]tg.KeyboardButton, 0)
 
 	for _, locality := range localities {
-		fullLocality := fmt.Sprintf("%s, %s", locality.Name, locality.RegionName)
-		keyboardButtons = append(keyboardButtons, []tg.KeyboardButton{{Text: fullLocality}})
+		keyboardButtons = append(keyboardButtons, []tg.KeyboardButton{{Text: localityText(locality)}})
 	}
 
-	keyboardButtons = append(keyboardButtons, []tg.KeyboardButton{{Text: m.Localize.Translate(btnOptionCancelTr, UALang)}})
+	keyboardButtons = append(keyboardButtons, []tg.KeyboardButton{{Text: m.Localize.Translate(btnOptionCancelTr, u.lang())}})
 
-	m.dialogs.get(u.chatID()).seeker.localities = localities
-	m.dialogs.get(u.chatID()).next = m.handleSeekerLocalityButtonReply
+	m.dialogs.get(u.dialogKey()).seeker.localities = localities
+	m.dialogs.get(u.dialogKey()).next = m.handleSeekerLocalityButtonReply
 
-	msg := tg.NewMessage(u.chatID(), m.Localize.Translate(userLocalityReplyTr, UALang))
+	msg := tg.NewMessage(u.chatID(), m.Localize.Translate(userLocalityReplyTr, u.lang()))
 	msg.ReplyMarkup = tg.ReplyKeyboardMarkup{
 		Keyboard:       keyboardButtons,
 		ResizeKeyboard: true,
@@ -197,93 +208,166 @@ func (m *MessageHandler) handleSeekerLocalityTextReply(u *Update) error {
 }
 
 func (m *MessageHandler) handleSeekerLocalityButtonReply(u *Update) error {
-	d := m.dialogs.get(u.chatID())
-
-	for _, l := range m.dialogs.get(u.chatID()).seeker.localities {
-		if fmt.Sprintf("%s, %s", l.Name, l.RegionName) == u.Message.Text {
-			d.seeker.locality = &l
-			break
+	for _, l := range m.dialogs.get(u.dialogKey()).seeker.localities {
+		if localityText(l) == u.Message.Text {
+			return m.handleSeekerLocality(u, l)
 		}
 	}
 
-	if d.seeker.locality == nil {
-		return m.handleSeekerLocalityTextReply(u)
+	return m.handleSeekerLocalityTextReply(u)
+}
+
+// handleSeekerLocality searches helps in the chosen locality.
+func (m *MessageHandler) handleSeekerLocality(u *Update, l service.Locality) error {
+	d := m.dialogs.get(u.dialogKey())
+	d.seeker.locality = &l
+	m.rememberLocality(u, l)
+
+	// group admins configure a subscription without searching
+	if d.seeker.chatID != nil {
+		return m.sendSubscriptionScopeRequest(u)
 	}
 
-	_, err := m.Api.Send(tg.NewMessage(u.chatID(), m.Localize.Translate(seekerLookingForVolunteersTr, UALang)))
+	_, err := m.Api.Send(tg.NewMessage(u.chatID(), m.Localize.Translate(seekerLookingForVolunteersTr, u.lang())))
 	if err != nil {
 		m.L.Error("send message", zap.Error(err))
 	}
 
-	helps, err := m.Service.HelpsByCategoryLocation(u.ctx, d.seeker.locality.ID, d.seeker.category.ID)
+	cids := d.seeker.categoryIDs()
+	found, err := m.sendHelpPager(u, m.newSearchPager(service.HelpQuery{
+		LocalityID:  l.ID,
+		CategoryIDs: cids,
+		Radius:      u.user().Preferences.SearchRadius,
+		Sort:        service.SortNewest,
+	}))
 	if err != nil {
 		return err
 	}
 
-	if len(helps) == 0 {
-		msg := tg.NewMessage(u.chatID(), fmt.Sprintf("%s\n\n%s", m.Localize.Translate(seekerHelpsEmptyTr, UALang), m.Localize.Translate(seekerSubscriptionProposalTr, UALang)))
-		msg.ReplyMarkup = tg.ReplyKeyboardMarkup{
-			Keyboard: [][]tg.KeyboardButton{{
-				{Text: m.Localize.Translate(btnOptionCancelTr, UALang)},
-				{Text: m.Localize.Translate(btnOptionSubscribeTr, UALang)},
-			}},
-			OneTimeKeyboard: true,
-			ResizeKeyboard:  true,
-		}
+	if !found {
+		msg := tg.NewMessage(u.chatID(), fmt.Sprintf("%s\n\n%s", m.Localize.Translate(seekerHelpsEmptyTr, u.lang()), m.Localize.Translate(seekerSubscriptionProposalTr, u.lang())))
+		msg.ReplyMarkup = m.seekerProposalKeyboard(u.lang())
 
 		d.next = m.handleSeekerSubscriptionBtnReply
 		_, err := m.Api.Send(msg)
 		return err
 	}
 
-	for _, help := range helps {
-		builder := strings.Builder{}
-		builder.WriteString(fmt.Sprintf("%s %s\n", emojiLocation, help.Locality))
-		builder.WriteString(fmt.Sprintf("%s %s\n", emojiTime, m.Localize.FormatDateTime(help.CreatedAt, UALang)))
-		for _, c := range help.Categories {
-			builder.WriteString(fmt.Sprintf("%s %s\n", emojiItem, c))
-		}
-		builder.WriteString(fmt.Sprintf("%s\n", help.Description))
-		msg := tg.NewMessage(u.chatID(), builder.String())
-		msg.ReplyMarkup = tg.ReplyKeyboardHide{HideKeyboard: true}
-		_, err = m.Api.Send(msg)
+	txt := fmt.Sprintf("%s\n", m.Localize.Translate(seekerSubscriptionProposalTr, u.lang()))
+	if link, err := m.Service.SearchLink(l.ID, cids); err == nil {
+		txt += fmt.Sprintf("\n%s %s", m.Localize.Translate(seekerSearchLinkTr, u.lang()), link)
+	}
+
+	msg := tg.NewMessage(u.chatID(), txt)
+	msg.ReplyMarkup = m.seekerProposalKeyboard(u.lang())
+
+	d.next = m.handleSeekerSubscriptionBtnReply
+	_, err = m.Api.Send(msg)
+	return err
+}
+
+// seekerProposalKeyboard offers to subscribe or to search the oblast by text after search results.
+func (m *MessageHandler) seekerProposalKeyboard(lang string) tg.ReplyKeyboardMarkup {
+	return tg.ReplyKeyboardMarkup{
+		Keyboard: [][]tg.KeyboardButton{
+			{
+				{Text: m.Localize.Translate(btnOptionCancelTr, lang)},
+				{Text: m.Localize.Translate(btnOptionSubscribeTr, lang)},
+			},
+			{{Text: m.Localize.Translate(btnOptionSearchTextTr, lang)}},
+		},
+		OneTimeKeyboard: true,
+		ResizeKeyboard:  true,
+	}
+}
+
+func (m *MessageHandler) handleSeekerSubscriptionBtnReply(u *Update) error {
+	switch u.Message.Text {
+	case m.Localize.Translate(btnOptionSubscribeTr, u.lang()):
+		return m.sendSubscriptionScopeRequest(u)
+	case m.Localize.Translate(btnOptionSearchTextTr, u.lang()):
+		err := m.sendSearchTextRequest(u)
 		if err != nil {
 			return err
 		}
+
+		m.dialogs.get(u.dialogKey()).next = m.handleSearchTextReply
+	}
+
+	return nil
+}
+
+// sendSubscriptionScopeRequest offers to subscribe on the chosen locality or one of its ancestors.
+func (m *MessageHandler) sendSubscriptionScopeRequest(u *Update) error {
+	d := m.dialogs.get(u.dialogKey())
+	scopes, err := m.Service.LocalityAncestors(u.ctx, d.seeker.locality.ID)
+	if err != nil {
+		return err
+	}
+
+	if len(scopes) <= 1 {
+		return m.createSeekerSubscription(u, d.seeker.locality.ID)
 	}
 
-	msg := tg.NewMessage(u.chatID(), fmt.Sprintf("%s\n", m.Localize.Translate(seekerSubscriptionProposalTr, UALang)))
+	keyboardButtons := make([][]tg.KeyboardButton, 0, len(scopes)+1)
+	for i, l := range scopes {
+		keyboardButtons = append(keyboardButtons, []tg.KeyboardButton{{Text: m.subscriptionScopeText(l, i == 0, u.lang())}})
+	}
+
+	keyboardButtons = append(keyboardButtons, []tg.KeyboardButton{{Text: m.Localize.Translate(btnOptionCancelTr, u.lang())}})
+
+	msg := tg.NewMessage(u.chatID(), m.Localize.Translate(seekerSubscriptionScopeRequestTr, u.lang()))
 	msg.ReplyMarkup = tg.ReplyKeyboardMarkup{
-		Keyboard: [][]tg.KeyboardButton{{
-			{Text: m.Localize.Translate(btnOptionCancelTr, UALang)},
-			{Text: m.Localize.Translate(btnOptionSubscribeTr, UALang)},
-		}},
-		OneTimeKeyboard: true,
-		ResizeKeyboard:  true,
+		Keyboard:       keyboardButtons,
+		ResizeKeyboard: true,
 	}
 
-	d.next = m.handleSeekerSubscriptionBtnReply
+	d.seeker.scopes = scopes
+	d.next = m.handleSeekerSubscriptionScopeReply
 	_, err = m.Api.Send(msg)
 	return err
 }
 
-func (m *MessageHandler) handleSeekerSubscriptionBtnReply(u *Update) error {
-	if u.Message.Text != m.Localize.Translate(btnOptionSubscribeTr, UALang) {
-		return nil
+func (m *MessageHandler) handleSeekerSubscriptionScopeReply(u *Update) error {
+	d := m.dialogs.get(u.dialogKey())
+	for i, l := range d.seeker.scopes {
+		if m.subscriptionScopeText(l, i == 0, u.lang()) == u.Message.Text {
+			return m.createSeekerSubscription(u, l.ID)
+		}
 	}
 
+	_, err := m.Api.Send(tg.NewMessage(u.chatID(), m.Localize.Translate(errorChooseOptionTr, u.lang())))
+	return err
+}
+
+// subscriptionScopeText returns button text for the chosen locality or one of its ancestors.
+func (m *MessageHandler) subscriptionScopeText(l service.Locality, chosen bool, lang string) string {
+	switch {
+	case chosen:
+		return fmt.Sprintf("%s %s", emojiLocation, l.Name)
+	case l.Type == service.LocalityTypeCountry:
+		return m.Localize.Translate(btnOptionWholeCountryTr, lang)
+	default:
+		return l.Name
+	}
+}
+
+func (m *MessageHandler) createSeekerSubscription(u *Update, localityID int) error {
 	uid, err := u.userUUID()
 	if err != nil {
 		return err
 	}
 
+	d := m.dialogs.get(u.dialogKey())
 	if err := m.Service.NewSubscription(u.ctx, service.CreateSubscription{
-		CreatorID:  uid,
-		CategoryID: m.dialogs.get(u.chatID()).seeker.category.ID,
-		LocalityID: m.dialogs.get(u.chatID()).seeker.locality.ID,
+		CreatorID:   uid,
+		CategoryIDs: d.seeker.categoryIDs(),
+		LocalityID:  localityID,
+		ChatID:      d.seeker.chatID,
+		Keywords:    d.seeker.keywords,
 	}); err != nil {
 		if errors.Is(err, service.ErrAlreadyExists) {
-			msg := tg.NewMessage(u.chatID(), fmt.Sprintf("%s\n", m.Localize.Translate(seekerSubscriptionAlreadyExistsTr, UALang)))
+			msg := tg.NewMessage(u.chatID(), fmt.Sprintf("%s\n", m.Localize.Translate(seekerSubscriptionAlreadyExistsTr, u.lang())))
 			_, err := m.Api.Send(msg)
 			return err
 		}
@@ -291,8 +375,13 @@ func (m *MessageHandler) handleSeekerSubscriptionBtnReply(u *Update) error {
 		return err
 	}
 
-	m.dialogs.delete(u.chatID())
-	msg := tg.NewMessage(u.chatID(), fmt.Sprintf("%s\n\n%s", m.Localize.Translate(seekerSubscriptionCreateSuccessTr, UALang), m.Localize.Translate(navigationHintTr, UALang)))
+	m.dialogs.delete(u.dialogKey())
+	txt := fmt.Sprintf("%s\n\n%s", m.Localize.Translate(seekerSubscriptionCreateSuccessTr, u.lang()), m.Localize.Translate(navigationHintTr, u.lang()))
+	if d.seeker.chatID != nil {
+		txt = m.Localize.Translate(groupSubscriptionCreateSuccessTr, u.lang())
+	}
+
+	msg := tg.NewMessage(u.chatID(), txt)
 	msg.ReplyMarkup = tg.ReplyKeyboardHide{HideKeyboard: true}
 	_, err = m.Api.Send(msg)
 	return err
@@ -0,0 +1,231 @@
+package bot
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	tg "github.com/go-telegram-bot-api/telegram-bot-api"
+	"github.com/rvkinc/uasocial/internal/service"
+)
+
+const (
+	settingsLocalitySet   = "set"
+	settingsLocalityClear = "clear"
+	settingsContactOn     = "on"
+	settingsContactOff    = "off"
+)
+
+// searchRadiusPresets are search radiuses in km a user can choose from, 0 searches the locality only
+var searchRadiusPresets = []int{0, 10, 25, 50, 100} // nolint:gochecknoglobals
+
+var languages = []struct{ lang, name string }{ // nolint:gochecknoglobals
+	{service.LanguageUA, "🇺🇦 Українська"},
+	{service.LanguageRU, "Русский"},
+	{service.LanguageEN, "English"},
+}
+
+// settings is populated while user chooses the default locality.
+type settings struct {
+	localities service.Localities
+}
+
+func (m *MessageHandler) handleCmdSettings(u *Update) error {
+	txt, keyboard := m.settingsMenu(u.user().Preferences, u.lang())
+	msg := tg.NewMessage(u.chatID(), txt)
+	msg.ReplyMarkup = keyboard
+	_, err := m.Api.Send(msg)
+	return err
+}
+
+// handleSettingsCallback applies chosen option and updates the menu in place.
+func (m *MessageHandler) handleSettingsCallback(u *Update, cq, value string) error {
+	uid, err := u.userUUID()
+	if err != nil {
+		return err
+	}
+
+	var (
+		lang = u.lang()
+		p    = u.user().Preferences
+	)
+
+	switch cq {
+	case cqSettingsLanguage:
+		err = m.Service.SetLanguage(u.ctx, uid, value)
+		if err != nil {
+			return err
+		}
+		lang = value
+
+	case cqSettingsNotifications:
+		return m.handleCmdNotifications(u)
+
+	case cqSettingsRadius:
+		p.SearchRadius, err = strconv.Atoi(value)
+		if err != nil {
+			return err
+		}
+
+	case cqSettingsContact:
+		p.ShareContact = value == settingsContactOn
+
+	case cqSettingsLocality:
+		if value == settingsLocalitySet {
+			m.dialogs.set(&dialog{next: m.handleSettingsLocalityTextReply, settings: new(settings)}, u.dialogKey())
+			msg := tg.NewMessage(u.chatID(), m.Localize.Translate(userLocalityRequestTr, lang))
+			msg.ReplyMarkup = tg.ReplyKeyboardMarkup{
+				Keyboard:       [][]tg.KeyboardButton{{{Text: m.Localize.Translate(btnOptionCancelTr, lang)}}},
+				ResizeKeyboard: true,
+			}
+			_, err = m.Api.Send(msg)
+			return err
+		}
+		p.DefaultLocality = nil
+	}
+
+	if cq != cqSettingsLanguage {
+		err = m.Service.SetPreferences(u.ctx, uid, p)
+		if err != nil {
+			return err
+		}
+	}
+
+	txt, keyboard := m.settingsMenu(p, lang)
+	edit := tg.NewEditMessageText(u.chatID(), u.CallbackQuery.Message.MessageID, txt)
+	edit.ReplyMarkup = &keyboard
+	_, err = m.Api.Send(edit)
+	return err
+}
+
+func (m *MessageHandler) handleSettingsLocalityTextReply(u *Update) error {
+	localities, err := m.Service.AutocompleteLocality(u.ctx, u.Message.Text)
+	if err != nil {
+		return err
+	}
+
+	if len(localities) == 0 {
+		_, err = m.Api.Send(tg.NewMessage(u.chatID(), m.Localize.Translate(errorPleaseTryAgainTr, u.lang())))
+		return err
+	}
+
+	keyboardButtons := make([][]tg.KeyboardButton, 0, len(localities)+1)
+	for _, locality := range localities {
+		keyboardButtons = append(keyboardButtons, []tg.KeyboardButton{{Text: localityText(locality)}})
+	}
+	keyboardButtons = append(keyboardButtons, []tg.KeyboardButton{{Text: m.Localize.Translate(btnOptionCancelTr, u.lang())}})
+
+	d := m.dialogs.get(u.dialogKey())
+	d.settings.localities = localities
+	d.next = m.handleSettingsLocalityButtonReply
+
+	msg := tg.NewMessage(u.chatID(), m.Localize.Translate(userLocalityReplyTr, u.lang()))
+	msg.ReplyMarkup = tg.ReplyKeyboardMarkup{
+		Keyboard:       keyboardButtons,
+		ResizeKeyboard: true,
+	}
+
+	_, err = m.Api.Send(msg)
+	return err
+}
+
+func (m *MessageHandler) handleSettingsLocalityButtonReply(u *Update) error {
+	for _, l := range m.dialogs.get(u.dialogKey()).settings.localities {
+		if localityText(l) != u.Message.Text {
+			continue
+		}
+
+		uid, err := u.userUUID()
+		if err != nil {
+			return err
+		}
+
+		l := l
+		p := u.user().Preferences
+		p.DefaultLocality = &l
+		err = m.Service.SetPreferences(u.ctx, uid, p)
+		if err != nil {
+			return err
+		}
+
+		m.dialogs.delete(u.dialogKey())
+		txt, keyboard := m.settingsMenu(p, u.lang())
+		msg := tg.NewMessage(u.chatID(), txt)
+		msg.ReplyMarkup = keyboard
+		_, err = m.Api.Send(msg)
+		return err
+	}
+
+	return m.handleSettingsLocalityTextReply(u)
+}
+
+func (m *MessageHandler) settingsMenu(p service.Preferences, lang string) (string, tg.InlineKeyboardMarkup) {
+	var (
+		b        strings.Builder
+		langs    = make([]tg.InlineKeyboardButton, 0, len(languages))
+		radiuses = make([]tg.InlineKeyboardButton, 0, len(searchRadiusPresets))
+	)
+
+	b.WriteString(fmt.Sprintf("%s\n\n", m.Localize.Translate(settingsHeaderTr, lang)))
+
+	for _, l := range languages {
+		text := l.name
+		if l.lang == lang {
+			b.WriteString(fmt.Sprintf("%s %s\n", m.Localize.Translate(settingsLanguageTr, lang), l.name))
+			text = emojiCheckbox + " " + text
+		}
+		langs = append(langs, tg.NewInlineKeyboardButtonData(text, fmt.Sprintf("%s|%s", cqSettingsLanguage, l.lang)))
+	}
+
+	for _, nm := range notificationModes {
+		if nm.mode == p.Mode {
+			b.WriteString(fmt.Sprintf("%s %s\n", m.Localize.Translate(settingsNotificationsTr, lang), m.Localize.Translate(nm.tr, lang)))
+		}
+	}
+
+	for _, r := range searchRadiusPresets {
+		text := m.searchRadiusText(r, lang)
+		if r == p.SearchRadius {
+			b.WriteString(fmt.Sprintf("%s %s\n", m.Localize.Translate(settingsSearchRadiusTr, lang), text))
+			text = emojiCheckbox + " " + text
+		}
+		radiuses = append(radiuses, tg.NewInlineKeyboardButtonData(text, fmt.Sprintf("%s|%d", cqSettingsRadius, r)))
+	}
+
+	locality := m.Localize.Translate(settingsNotSetTr, lang)
+	if p.DefaultLocality != nil {
+		locality = localityText(*p.DefaultLocality)
+	}
+	b.WriteString(fmt.Sprintf("%s %s\n", m.Localize.Translate(settingsDefaultLocalityTr, lang), locality))
+
+	localityRow := []tg.InlineKeyboardButton{
+		tg.NewInlineKeyboardButtonData(m.Localize.Translate(btnOptionSetDefaultLocalityTr, lang), fmt.Sprintf("%s|%s", cqSettingsLocality, settingsLocalitySet)),
+	}
+	if p.DefaultLocality != nil {
+		localityRow = append(localityRow, tg.NewInlineKeyboardButtonData(m.Localize.Translate(btnOptionClearDefaultLocalityTr, lang), fmt.Sprintf("%s|%s", cqSettingsLocality, settingsLocalityClear)))
+	}
+
+	contact, toggle := m.Localize.Translate(settingsNoTr, lang), settingsContactOn
+	if p.ShareContact {
+		contact, toggle = m.Localize.Translate(settingsYesTr, lang), settingsContactOff
+	}
+	b.WriteString(fmt.Sprintf("%s %s\n", m.Localize.Translate(settingsShareContactTr, lang), contact))
+	b.WriteString(fmt.Sprintf("\n%s", m.Localize.Translate(settingsHintTr, lang)))
+
+	keyboard := [][]tg.InlineKeyboardButton{
+		langs,
+		{tg.NewInlineKeyboardButtonData(m.Localize.Translate(btnOptionNotificationsTr, lang), fmt.Sprintf("%s|%s", cqSettingsNotifications, cmdNotifications))},
+		radiuses,
+		localityRow,
+		{tg.NewInlineKeyboardButtonData(m.Localize.Translate(btnOptionToggleShareContactTr, lang), fmt.Sprintf("%s|%s", cqSettingsContact, toggle))},
+	}
+
+	return b.String(), tg.InlineKeyboardMarkup{InlineKeyboard: keyboard}
+}
+
+func (m *MessageHandler) searchRadiusText(r int, lang string) string {
+	if r == 0 {
+		return m.Localize.Translate(btnOptionRadiusOffTr, lang)
+	}
+	return fmt.Sprintf(m.Localize.Translate(btnOptionRadiusTr, lang), r)
+}
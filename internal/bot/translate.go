@@ -22,13 +22,40 @@ const (
 	seekerSubscriptionCreateSuccessTr = "seeker_subscription_create_success"
 	seekerSubscriptionAlreadyExistsTr = "seeker_subscription_already_exists"
 	seekerSubscriptionUpdateHeaderTr  = "seeker_subscription_update_header"
+	seekerSubscriptionScopeRequestTr  = "seeker_subscription_scope_request"
+	seekerSubscriptionLinkConfirmTr   = "seeker_subscription_link_confirm"
+	seekerSearchLinkTr                = "seeker_search_link"
 
-	volunteerChosenCategoriesHeaderTr  = "volunteer_chosen_categories_header"
-	volunteerChosenCategoriesFooterTr  = "volunteer_chosen_categories_footer"
 	volunteerEnterDescriptionRequestTr = "volunteer_enter_description_request"
 	volunteerSummaryHeaderTr           = "volunteer_summary_header"
 	volunteerSummaryFooterTr           = "volunteer_summary_footer"
 	volunteerSelectCategoriesRequestTr = "volunteer_select_categories_request"
+	volunteerAttachmentAddedTr         = "volunteer_attachment_added"
+	volunteerDetailsRequestTr          = "volunteer_details_request"
+	volunteerContactRequestTr          = "volunteer_contact_request"
+	volunteerContactAddedTr            = "volunteer_contact_added"
+	volunteerCapacityUnitRequestTr     = "volunteer_capacity_unit_request"
+	volunteerCapacityRequestTr         = "volunteer_capacity_request"
+	volunteerAvailabilityRequestTr     = "volunteer_availability_request"
+	volunteerLanguagesRequestTr        = "volunteer_languages_request"
+	volunteerDuplicateWarningTr        = "volunteer_duplicate_warning"
+	volunteerHelpUpdatedTr             = "volunteer_help_updated"
+	volunteerHelpHeldTr                = "volunteer_help_held"
+	volunteerHelpApprovedTr            = "volunteer_help_approved"
+	volunteerHelpRejectedTr            = "volunteer_help_rejected"
+	helpPendingTr                      = "help_pending"
+	volunteerOrganisationRequestTr     = "volunteer_organisation_request"
+	organisationVerifiedTr             = "organisation_verified"
+	feedbackRequestTr                  = "feedback_request"
+	feedbackThanksTr                   = "feedback_thanks"
+	feedbackReportedTr                 = "feedback_reported"
+	feedbackDoneTr                     = "feedback_done"
+	capacitySeatsTr                    = "capacity_seats"
+	capacityBedsTr                     = "capacity_beds"
+	capacityKgTr                       = "capacity_kg"
+	availableFromTr                    = "available_from"
+	availableToTr                      = "available_to"
+	helpLinkTr                         = "help_link"
 
 	btnOptionRoleSeekerTr        = "btn_option_role_seeker"
 	btnOptionUserVolunteerTr     = "btn_option_role_volunteer"
@@ -37,6 +64,55 @@ const (
 	btnOptionDeleteTr            = "btn_option_delete"
 	btnOptionCancelTr            = "btn_option_cancel"
 	btnOptionHelpsBySubscription = "btn_optin_helps_by_subscription"
+	btnOptionBackTr              = "btn_option_back"
+	btnOptionAllInCategoryTr     = "btn_option_all_in_category"
+	btnOptionWholeCountryTr      = "btn_option_whole_country"
+	btnOptionViewHelpTr          = "btn_option_view_help"
+	btnOptionSendLocationTr      = "btn_option_send_location"
+	btnOptionOpenInBotTr         = "btn_option_open_in_bot"
+	btnOptionDeleteHelpTr        = "btn_option_delete_help"
+	btnOptionSortNewestTr        = "btn_option_sort_newest"
+	btnOptionSortNearestTr       = "btn_option_sort_nearest"
+	btnOptionSortConfirmedTr     = "btn_option_sort_confirmed"
+	btnOptionPostedDayTr         = "btn_option_posted_day"
+	btnOptionPostedWeekTr        = "btn_option_posted_week"
+	btnOptionHasPhoneTr          = "btn_option_has_phone"
+	btnOptionSearchTextTr        = "btn_option_search_text"
+	btnOptionCapacityTr          = "btn_option_capacity"
+	btnOptionAvailabilityTr      = "btn_option_availability"
+	btnOptionLanguagesTr         = "btn_option_languages"
+	btnOptionPublishTr           = "btn_option_publish"
+	btnOptionCapacitySeatsTr     = "btn_option_capacity_seats"
+	btnOptionCapacityBedsTr      = "btn_option_capacity_beds"
+	btnOptionCapacityKgTr        = "btn_option_capacity_kg"
+	btnOptionAvailableNowTr      = "btn_option_available_now"
+	btnOptionHasCapacityTr       = "btn_option_has_capacity"
+	btnOptionMyLanguageTr        = "btn_option_my_language"
+	btnOptionShowContactTr       = "btn_option_show_contact"
+	btnOptionSharePhoneTr        = "btn_option_share_phone"
+	btnOptionUseUsernameTr       = "btn_option_use_username"
+	btnOptionUpdateExistingTr    = "btn_option_update_existing"
+	btnOptionPublishAnywayTr     = "btn_option_publish_anyway"
+	btnOptionApproveTr           = "btn_option_approve"
+	btnOptionRejectTr            = "btn_option_reject"
+	btnOptionOrganisationTr      = "btn_option_organisation"
+	btnOptionPersonalHelpTr      = "btn_option_personal_help"
+	btnOptionVerifiedOnlyTr      = "btn_option_verified_only"
+	btnOptionFeedbackYesTr       = "btn_option_feedback_yes"
+	btnOptionFeedbackNoTr        = "btn_option_feedback_no"
+	btnOptionFeedbackReportTr    = "btn_option_feedback_report"
+
+	btnOptionNotificationInstantTr = "btn_option_notification_instant"
+	btnOptionNotificationHourlyTr  = "btn_option_notification_hourly"
+	btnOptionNotificationDailyTr   = "btn_option_notification_daily"
+	btnOptionQuietHoursOffTr       = "btn_option_quiet_hours_off"
+
+	btnOptionNotificationsTr        = "btn_option_notifications"
+	btnOptionRadiusTr               = "btn_option_radius"
+	btnOptionRadiusOffTr            = "btn_option_radius_off"
+	btnOptionSetDefaultLocalityTr   = "btn_option_set_default_locality"
+	btnOptionClearDefaultLocalityTr = "btn_option_clear_default_locality"
+	btnOptionToggleShareContactTr   = "btn_option_toggle_share_contact"
 
 	deleteHelpSuccessTr         = "delete_help_success"
 	deleteSubscriptionSuccessTr = "delete_subscription_success"
@@ -49,18 +125,79 @@ const (
 	errorHelpsLimitExceededTr         = "error_helps_limit_exceeded"
 	errorSubscriptionsLimitExceededTr = "error_subscriptions_limit_exceeded"
 	errorSubscriptionDoesNotExistTr   = "error_subscription_does_not_exist"
+	errorHelpDoesNotExistTr           = "error_help_does_not_exist"
+	errorLinkExpiredTr                = "error_link_expired"
+	errorPageExpiredTr                = "error_page_expired"
+	errorAttachmentsLimitExceededTr   = "error_attachments_limit_exceeded"
+	errorAvailabilityFormatTr         = "error_availability_format"
+	errorPhoneFormatTr                = "error_phone_format"
+	errorContactUnavailableTr         = "error_contact_unavailable"
 
 	cmdSupportTr                    = "cmd_support"
 	cmdStartActivityHeaderTr        = "cmd_start_activity_header"
 	cmdStartActivityHelpsTr         = "cmd_start_activity_helps"
 	cmdStartActivitySubscriptionsTr = "cmd_start_activity_subscriptions"
 
+	notificationsModeTr          = "notifications_mode"
+	notificationsQuietHoursTr    = "notifications_quiet_hours"
+	notificationsHintTr          = "notifications_hint"
+	digestHeaderTr               = "digest_header"
+	inlineSwitchPMTr             = "inline_switch_pm"
+	helpsPageTr                  = "helps_page"
+	searchTextRequestTr          = "search_text_request"
+	searchEmptyTr                = "search_empty"
+	searchSubscriptionProposalTr = "search_subscription_proposal"
+
+	settingsHeaderTr          = "settings_header"
+	settingsLanguageTr        = "settings_language"
+	settingsNotificationsTr   = "settings_notifications"
+	settingsSearchRadiusTr    = "settings_search_radius"
+	settingsDefaultLocalityTr = "settings_default_locality"
+	settingsShareContactTr    = "settings_share_contact"
+	settingsNotSetTr          = "settings_not_set"
+	settingsYesTr             = "settings_yes"
+	settingsNoTr              = "settings_no"
+	settingsHintTr            = "settings_hint"
+
+	groupSubscriptionCreateSuccessTr  = "group_subscription_create_success"
+	groupSubscriptionsEmptyTr         = "group_subscriptions_empty"
+	groupSubscriptionsLimitExceededTr = "group_subscriptions_limit_exceeded"
+	errorPrivateChatOnlyTr            = "error_private_chat_only"
+	errorGroupChatOnlyTr              = "error_group_chat_only"
+	errorGroupAdminOnlyTr             = "error_group_admin_only"
+
+	chosenCategoriesHeaderTr = "chosen_categories_header"
+	chosenCategoriesFooterTr = "chosen_categories_footer"
+
 	navigationHintTr = "navigation_hint"
+
+	adminCategoryListHeaderTr    = "admin_category_list_header"
+	adminCategoryUsageTr         = "admin_category_usage"
+	adminCategoryNotFoundTr      = "admin_category_not_found"
+	adminCategoryAlreadyExistsTr = "admin_category_already_exists"
+
+	adminModerationHeaderTr          = "admin_moderation_header"
+	adminModerationEmptyTr           = "admin_moderation_empty"
+	adminModerationApprovedTr        = "admin_moderation_approved"
+	adminModerationRejectedTr        = "admin_moderation_rejected"
+	adminModerationDoneTr            = "admin_moderation_done"
+	adminModerationUsageTr           = "admin_moderation_usage"
+	adminBannedPhrasesHeaderTr       = "admin_banned_phrases_header"
+	adminBannedPhraseNotFoundTr      = "admin_banned_phrase_not_found"
+	adminBannedPhraseAlreadyExistsTr = "admin_banned_phrase_already_exists"
+	adminTierUsageTr                 = "admin_tier_usage"
+	adminTierUpdatedTr               = "admin_tier_updated"
+	adminUserNotFoundTr              = "admin_user_not_found"
+	adminOrganisationListHeaderTr    = "admin_organisation_list_header"
+	adminOrganisationUsageTr         = "admin_organisation_usage"
+	adminOrganisationNotFoundTr      = "admin_organisation_not_found"
+	adminOrganisationAlreadyExistsTr = "admin_organisation_already_exists"
 )
 
 const (
-	weekDaysKey = "week_days"
-	monthKey    = "months"
+	weekDaysKey      = "week_days"
+	weekDaysShortKey = "week_days_short"
+	monthKey         = "months"
 )
 
 //go:embed translation.json
@@ -94,7 +231,13 @@ func NewLocalizer() (*Localizer, error) {
 	return l, nil
 }
 
-func (l *Localizer) Translate(key, lang string) string { return l.textKeys[key][lang] }
+// Translate returns text in lang falling back to ukrainian if there is no translation.
+func (l *Localizer) Translate(key, lang string) string {
+	if txt, ok := l.textKeys[key][lang]; ok {
+		return txt
+	}
+	return l.textKeys[key][UALang]
+}
 
 func (l *Localizer) FormatDateTime(t time.Time, lang string) string {
 	return fmt.Sprintf("%s %s", l.FormatDate(t, lang), l.FormatTime(t))
@@ -109,9 +252,21 @@ func (l *Localizer) FormatDate(t time.Time, lang string) string {
 }
 
 func (l *Localizer) Month(month time.Month, lang string) string {
-	return l.timeKeys[monthKey][lang][month-1]
+	return l.dt(monthKey, lang)[month-1]
 }
 
 func (l *Localizer) WeekDay(weekday time.Weekday, lang string) string {
-	return l.timeKeys[weekDaysKey][lang][weekday]
+	return l.dt(weekDaysKey, lang)[weekday]
+}
+
+// WeekDayShort returns lowercase abbreviation of the weekday.
+func (l *Localizer) WeekDayShort(weekday time.Weekday, lang string) string {
+	return l.dt(weekDaysShortKey, lang)[weekday]
+}
+
+func (l *Localizer) dt(key, lang string) []string {
+	if names, ok := l.timeKeys[key][lang]; ok {
+		return names
+	}
+	return l.timeKeys[key][UALang]
 }
@@ -2,6 +2,7 @@ package bot
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
@@ -12,16 +13,43 @@ import (
 	"go.uber.org/zap"
 )
 
-const (
-	maxHelpsPerUser = 2
-)
-
 type volunteer struct {
-	categories       []*category
-	categoryKeyboard []*categoryCheckbox
-	localities       service.Localities
-	locality         service.Locality
-	description      string
+	categories  *categoryPicker
+	localities  service.Localities
+	locality    service.Locality
+	description string
+	attachments []service.Attachment
+	details     service.HelpDetails
+	contact     service.Contact
+	duplicate   *service.UserHelp // existing help the volunteer was warned about
+
+	album    int // bumped by every attachment, only the latest one sends the confirmation
+	rejected int // attachments over the limit since the last confirmation
+
+	organisations []service.Organisation // the volunteer is a member of, loaded with details menu
+	organisation  *service.Organisation  // the help is posted on behalf of
+}
+
+func (v *volunteer) newHelp(uid uuid.UUID) service.NewHelp {
+	selected := v.categories.selected()
+	cids := make([]uuid.UUID, 0, len(selected))
+	for _, cs := range selected {
+		cids = append(cids, cs.ID)
+	}
+
+	help := service.NewHelp{
+		CreatorID:   uid,
+		CategoryIDs: cids,
+		LocalityID:  v.locality.ID,
+		Description: v.description,
+		Attachments: v.attachments,
+		Details:     v.details,
+		Contact:     v.contact,
+	}
+	if v.organisation != nil {
+		help.OrganisationID = &v.organisation.ID
+	}
+	return help
 }
 
 // command
@@ -32,45 +60,21 @@ func (m *MessageHandler) handleCmdMyHelp(u *Update) error {
 		return fmt.Errorf("no user in context")
 	}
 
-	helps, err := m.Service.UserHelps(u.ctx, uid)
+	ok, err := m.sendHelpPager(u, newHelpPager(func(ctx context.Context, after *service.Cursor) (service.HelpPage, error) {
+		return m.Service.UserHelps(ctx, uid, after, helpsPerPage)
+	}, true))
 	if err != nil {
 		return fmt.Errorf("get user helps: %w", err)
 	}
 
-	if len(helps) == 0 {
-		msg := tg.NewMessage(u.chatID(), fmt.Sprintf("%s\n\n%s", m.Localize.Translate(errorNoHelpsTr, UALang), m.Localize.Translate(navigationHintTr, UALang)))
-		msg.ReplyMarkup = tg.ReplyKeyboardHide{HideKeyboard: true}
-		_, err = m.Api.Send(msg)
-		return err
-	}
-
-	for _, h := range helps {
-		var b strings.Builder
-		b.WriteString(fmt.Sprintf("%s %s\n", emojiLocation, h.Locality))
-		b.WriteString(fmt.Sprintf("%s %s\n", emojiTime, m.Localize.FormatDateTime(h.CreatedAt, UALang)))
-		for _, c := range h.Categories {
-			b.WriteString(fmt.Sprintf("%s %s\n", emojiItem, c))
-		}
-		b.WriteString(fmt.Sprintf("%s\n\n", h.Description))
-
-		queryString := fmt.Sprintf("%s|%s", cmdMyHelp, h.ID.String())
-		msg := tg.NewMessage(u.chatID(), b.String())
-		msg.ParseMode = "HTML"
-		msg.ReplyMarkup = tg.InlineKeyboardMarkup{InlineKeyboard: [][]tg.InlineKeyboardButton{
-			{
-				{
-					Text:         m.Localize.Translate(btnOptionDeleteTr, UALang),
-					CallbackData: &queryString,
-				},
-			},
-		}}
-		_, err := m.Api.Send(msg)
-		if err != nil {
-			return err
-		}
+	if ok {
+		return nil
 	}
 
-	return nil
+	msg := tg.NewMessage(u.chatID(), fmt.Sprintf("%s\n\n%s", m.Localize.Translate(errorNoHelpsTr, u.lang()), m.Localize.Translate(navigationHintTr, u.lang())))
+	msg.ReplyMarkup = tg.ReplyKeyboardHide{HideKeyboard: true}
+	_, err = m.Api.Send(msg)
+	return err
 }
 
 func (m *MessageHandler) handleVolunteerUserRoleReply(u *Update) error {
@@ -84,30 +88,25 @@ func (m *MessageHandler) handleVolunteerUserRoleReply(u *Update) error {
 		return err
 	}
 
-	if count >= maxHelpsPerUser && u.tgUser().ID != adminTgID {
-		m.dialogs.delete(u.chatID())
-		msg := tg.NewMessage(u.chatID(), fmt.Sprintf(m.Localize.Translate(errorHelpsLimitExceededTr, UALang), maxHelpsPerUser))
+	limit := m.Service.UserLimits(u.tier()).HelpsPerUser
+	if count >= limit {
+		m.dialogs.delete(u.dialogKey())
+		msg := tg.NewMessage(u.chatID(), fmt.Sprintf(m.Localize.Translate(errorHelpsLimitExceededTr, u.lang()), limit))
 		msg.ReplyMarkup = tg.ReplyKeyboardHide{HideKeyboard: true}
 		_, err = m.Api.Send(msg)
 		return err
 	}
 
-	d := m.dialogs.get(u.chatID())
+	d := m.dialogs.get(u.dialogKey())
 	d.role = roleVolunteer
 	d.volunteer = new(volunteer)
-	d.volunteer.categoryKeyboard = make([]*categoryCheckbox, 0, len(m.categories))
-	for _, cc := range m.categories {
-		d.volunteer.categoryKeyboard = append(d.volunteer.categoryKeyboard, &categoryCheckbox{
-			category: category{uid: cc.ID, text: cc.Name},
-			checked:  false,
-		})
-	}
+	d.volunteer.categories = m.newCategoryPicker(u.lang())
 
-	msg := tg.NewMessage(u.chatID(), m.Localize.Translate(volunteerSelectCategoriesRequestTr, UALang))
+	msg := tg.NewMessage(u.chatID(), m.Localize.Translate(volunteerSelectCategoriesRequestTr, u.lang()))
 	msg.ReplyMarkup = tg.ReplyKeyboardMarkup{
 		OneTimeKeyboard: false,
 		ResizeKeyboard:  true,
-		Keyboard:        d.volunteer.categoryKeyboardLayout(m.Localize.Translate(btnOptionCancelTr, UALang), ""),
+		Keyboard:        d.volunteer.categories.layout(m.Localize.Translate(btnOptionCancelTr, u.lang()), ""),
 	}
 
 	_, err = m.Api.Send(msg)
@@ -115,86 +114,51 @@ func (m *MessageHandler) handleVolunteerUserRoleReply(u *Update) error {
 		return err
 	}
 
-	m.dialogs.get(u.chatID()).next = m.handleVolunteerCategoryCheckboxReply
+	m.dialogs.get(u.dialogKey()).next = m.handleVolunteerCategoryCheckboxReply
 	return nil
 }
 
 func (m *MessageHandler) handleVolunteerCategoryCheckboxReply(u *Update) error {
-	d := m.dialogs.get(u.chatID())
-	nextBtnText := m.Localize.Translate(btnOptionNextTr, UALang)
-
-	if u.Message.Text == nextBtnText && len(d.volunteer.categories) > 0 {
-		msg := tg.NewMessage(u.chatID(), m.Localize.Translate(userLocalityRequestTr, UALang))
-		msg.ReplyMarkup = tg.ReplyKeyboardMarkup{
-			Keyboard: [][]tg.KeyboardButton{{
-				{Text: m.Localize.Translate(btnOptionCancelTr, UALang)},
-			}},
-			ResizeKeyboard: true,
-		}
-		_, err := m.Api.Send(msg)
-		d.next = m.handleVolunteerLocalityTextReply
-		return err
-	}
-
-	_, ok := d.volunteer.invertCategoryButton(u.Message.Text)
-	if !ok {
-		// garbage value
-		_, err := m.Api.Send(tg.NewMessage(u.chatID(), m.Localize.Translate(errorChooseOptionTr, UALang)))
-		if err != nil {
-			return err
-		}
+	d := m.dialogs.get(u.dialogKey())
+	nextBtnText := m.Localize.Translate(btnOptionNextTr, u.lang())
 
-		return nil
+	if u.Message.Text == nextBtnText && len(d.volunteer.categories.selected()) > 0 {
+		d.next = m.handleVolunteerLocalityTextReply
+		return m.sendLocalityRequest(u)
 	}
 
-	var txt string
-	if len(d.volunteer.categories) != 0 {
-		txt = fmt.Sprintf("%s:\n\n", m.Localize.Translate(volunteerChosenCategoriesHeaderTr, UALang))
-		for _, c := range d.volunteer.categories {
-			txt += fmt.Sprintf("%s %s\n", emojiItem, c.text)
-		}
-		txt += fmt.Sprintf("%s %s", m.Localize.Translate(volunteerChosenCategoriesFooterTr, UALang), m.Localize.Translate(btnOptionNextTr, UALang))
-	} else {
-		txt = m.Localize.Translate(errorChooseOptionTr, UALang)
-	}
+	return m.handleCategoryPickerReply(u, d.volunteer.categories, volunteerSelectCategoriesRequestTr)
+}
 
-	// show or hide next button
-	nextbtn := ""
-	if len(d.volunteer.categories) > 0 {
-		nextbtn = m.Localize.Translate(btnOptionNextTr, UALang)
+func (m *MessageHandler) handleVolunteerLocalityTextReply(u *Update) error {
+	l, ok, err := m.shortcutLocality(u)
+	if err != nil {
+		return err
 	}
 
-	msg := tg.NewMessage(u.chatID(), txt)
-	msg.ReplyMarkup = tg.ReplyKeyboardMarkup{
-		OneTimeKeyboard: false,
-		ResizeKeyboard:  true,
-		Keyboard:        d.volunteer.categoryKeyboardLayout(m.Localize.Translate(btnOptionCancelTr, UALang), nextbtn),
+	if ok {
+		return m.handleVolunteerLocality(u, l)
 	}
 
-	_, err := m.Api.Send(msg)
-	return err
-}
-
-func (m *MessageHandler) handleVolunteerLocalityTextReply(u *Update) error {
-	localities, err := m.Service.AutocompleteLocality(u.ctx, strings.Title(strings.ToLower(u.Message.Text)))
+	localities, err := m.Service.AutocompleteLocality(u.ctx, u.Message.Text)
 	if err != nil {
 		return err
 	}
 
 	if len(localities) == 0 {
-		msg := tg.NewMessage(u.chatID(), m.Localize.Translate(errorPleaseTryAgainTr, UALang))
+		msg := tg.NewMessage(u.chatID(), m.Localize.Translate(errorPleaseTryAgainTr, u.lang()))
 		_, err := m.Api.Send(msg)
 		return err
 	}
 
 	keyboardButtons := make([][]tg.KeyboardButton, 0)
 	for _, locality := range localities {
-		keyboardButtons = append(keyboardButtons, []tg.KeyboardButton{{Text: fmt.Sprintf("%s, %s", locality.Name, locality.RegionName)}})
+		keyboardButtons = append(keyboardButtons, []tg.KeyboardButton{{Text: localityText(locality)}})
 	}
 
-	keyboardButtons = append(keyboardButtons, []tg.KeyboardButton{{Text: m.Localize.Translate(btnOptionCancelTr, UALang)}})
+	keyboardButtons = append(keyboardButtons, []tg.KeyboardButton{{Text: m.Localize.Translate(btnOptionCancelTr, u.lang())}})
 
-	msg := tg.NewMessage(u.chatID(), m.Localize.Translate(userLocalityReplyTr, UALang))
+	msg := tg.NewMessage(u.chatID(), m.Localize.Translate(userLocalityReplyTr, u.lang()))
 	msg.ReplyMarkup = tg.ReplyKeyboardMarkup{
 		Keyboard:       keyboardButtons,
 		ResizeKeyboard: true,
@@ -205,72 +169,166 @@ func (m *MessageHandler) handleVolunteerLocalityTextReply(u *Update) error {
 		return err
 	}
 
-	m.dialogs.get(u.chatID()).volunteer.localities = localities
-	m.dialogs.get(u.chatID()).next = m.handleVolunteerLocalityButtonReply
+	m.dialogs.get(u.dialogKey()).volunteer.localities = localities
+	m.dialogs.get(u.dialogKey()).next = m.handleVolunteerLocalityButtonReply
 
 	return nil
 }
 
 func (m *MessageHandler) handleVolunteerLocalityButtonReply(u *Update) error {
-	d := m.dialogs.get(u.chatID())
-	for _, l := range d.volunteer.localities {
-		if fmt.Sprintf("%s, %s", l.Name, l.RegionName) == u.Message.Text {
-			d.volunteer.locality = l
-			d.next = m.handleVolunteerDescriptionTextReply
-			msg := tg.NewMessage(u.chatID(), m.Localize.Translate(volunteerEnterDescriptionRequestTr, UALang))
-			msg.ReplyMarkup = tg.ReplyKeyboardMarkup{
-				Keyboard: [][]tg.KeyboardButton{{
-					{Text: m.Localize.Translate(btnOptionCancelTr, UALang)},
-				}},
-				ResizeKeyboard:  true,
-				OneTimeKeyboard: true,
-			}
-			_, err := m.Api.Send(msg)
-			return err
+	for _, l := range m.dialogs.get(u.dialogKey()).volunteer.localities {
+		if localityText(l) == u.Message.Text {
+			return m.handleVolunteerLocality(u, l)
 		}
 	}
 
 	return m.handleVolunteerLocalityTextReply(u)
 }
 
+func (m *MessageHandler) handleVolunteerLocality(u *Update, l service.Locality) error {
+	d := m.dialogs.get(u.dialogKey())
+	d.volunteer.locality = l
+	d.next = m.handleVolunteerDescriptionTextReply
+	m.rememberLocality(u, l)
+
+	msg := tg.NewMessage(u.chatID(), m.Localize.Translate(volunteerEnterDescriptionRequestTr, u.lang()))
+	msg.ReplyMarkup = tg.ReplyKeyboardMarkup{
+		Keyboard: [][]tg.KeyboardButton{{
+			{Text: m.Localize.Translate(btnOptionCancelTr, u.lang())},
+		}},
+		ResizeKeyboard:  true,
+		OneTimeKeyboard: true,
+	}
+	_, err := m.Api.Send(msg)
+	return err
+}
+
+// albumDelay is how long the bot waits for the rest of the album before confirming attachments,
+// album items arrive as separate messages.
+const albumDelay = time.Second
+
+// handleVolunteerAttachmentReply adds photo or document sent at the description step, its caption is kept as description.
+// The confirmation is delayed so an album gets a single one.
+func (m *MessageHandler) handleVolunteerAttachmentReply(u *Update, a service.Attachment) error {
+	key := u.dialogKey()
+	d := m.dialogs.get(key)
+	if len(d.volunteer.attachments) >= service.MaxHelpAttachments {
+		d.volunteer.rejected++
+	} else {
+		d.volunteer.attachments = append(d.volunteer.attachments, a)
+	}
+
+	if u.Message.Caption != "" {
+		d.volunteer.description = u.Message.Caption
+	}
+
+	d.volunteer.album++
+	album := d.volunteer.album
+	time.AfterFunc(albumDelay, func() {
+		defer m.dialogs.lock(key)()
+		// dialog was cancelled, moved on or got more attachments meanwhile
+		if m.dialogs.get(key) != d || d.volunteer.album != album {
+			return
+		}
+
+		err := m.confirmVolunteerAttachments(u, d)
+		if err != nil {
+			m.L.Error("confirm attachments", zap.Error(err))
+		}
+	})
+	return nil
+}
+
+func (m *MessageHandler) confirmVolunteerAttachments(u *Update, d *dialog) error {
+	if d.volunteer.rejected > 0 {
+		d.volunteer.rejected = 0
+		_, err := m.Api.Send(tg.NewMessage(u.chatID(), fmt.Sprintf(m.Localize.Translate(errorAttachmentsLimitExceededTr, u.lang()), service.MaxHelpAttachments)))
+		if err != nil {
+			return err
+		}
+	}
+
+	buttons := []tg.KeyboardButton{{Text: m.Localize.Translate(btnOptionCancelTr, u.lang())}}
+	if d.volunteer.description != "" {
+		buttons = append(buttons, tg.KeyboardButton{Text: m.Localize.Translate(btnOptionNextTr, u.lang())})
+	}
+
+	msg := tg.NewMessage(u.chatID(), fmt.Sprintf(m.Localize.Translate(volunteerAttachmentAddedTr, u.lang()), len(d.volunteer.attachments), service.MaxHelpAttachments))
+	msg.ReplyMarkup = tg.ReplyKeyboardMarkup{
+		Keyboard:       [][]tg.KeyboardButton{buttons},
+		ResizeKeyboard: true,
+	}
+	_, err := m.Api.Send(msg)
+	return err
+}
+
 func (m *MessageHandler) handleVolunteerDescriptionTextReply(u *Update) error {
-	d := m.dialogs.get(u.chatID())
-	d.volunteer.description = u.Message.Text
+	if a, ok := messageAttachment(u.Message); ok {
+		return m.handleVolunteerAttachmentReply(u, a)
+	}
 
-	var b strings.Builder
-	b.WriteString(fmt.Sprintf("%s\n\n", m.Localize.Translate(volunteerSummaryHeaderTr, UALang)))
-	b.WriteString(fmt.Sprintf("%s %s, %s\n", emojiLocation, d.volunteer.locality.Name, d.volunteer.locality.RegionName))
-	b.WriteString(fmt.Sprintf("%s %s\n", emojiTime, m.Localize.FormatDateTime(time.Now(), UALang)))
-	for _, c := range d.volunteer.categories {
-		b.WriteString(fmt.Sprintf("%s %s\n", emojiItem, c.text))
+	d := m.dialogs.get(u.dialogKey())
+	d.volunteer.album++ // drops pending attachments confirmation
+	if u.Message.Text != m.Localize.Translate(btnOptionNextTr, u.lang()) || d.volunteer.description == "" {
+		d.volunteer.description = u.Message.Text
 	}
-	b.WriteString(fmt.Sprintf("%s\n\n", d.volunteer.description))
-	b.WriteString(fmt.Sprintf("%s", m.Localize.Translate(navigationHintTr, UALang)))
+
+	if d.volunteer.description == "" {
+		_, err := m.Api.Send(tg.NewMessage(u.chatID(), m.Localize.Translate(errorPleaseTryAgainTr, u.lang())))
+		return err
+	}
+
+	return m.sendVolunteerContactRequest(u, volunteerContactRequestTr)
+}
+
+// publishVolunteerHelp creates help collected by the dialog and sends its summary,
+// the volunteer is warned first if a similar help already exists.
+func (m *MessageHandler) publishVolunteerHelp(u *Update) error {
+	d := m.dialogs.get(u.dialogKey())
 
 	uid, err := u.userUUID()
 	if err != nil {
 		return err
 	}
 
-	go func() {
-		cids := make([]uuid.UUID, 0, len(d.volunteer.categories))
-		for _, cs := range d.volunteer.categories {
-			cids = append(cids, cs.uid)
+	help := d.volunteer.newHelp(uid)
+	if d.volunteer.duplicate == nil {
+		dup, err := m.Service.FindDuplicateHelp(u.ctx, help)
+		switch {
+		case err == nil:
+			d.volunteer.duplicate = &dup
+			return m.sendVolunteerDuplicateWarning(u)
+		case !errors.Is(err, service.ErrNotFound):
+			return err
 		}
+	}
 
-		err := m.Service.NewHelp(context.Background(), service.NewHelp{
-			CreatorID:   uid,
-			CategoryIDs: cids,
-			LocalityID:  d.volunteer.locality.ID,
-			Description: d.volunteer.description,
-		})
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("%s\n\n", m.Localize.Translate(volunteerSummaryHeaderTr, u.lang())))
+	b.WriteString(fmt.Sprintf("%s %s, %s\n", emojiLocation, d.volunteer.locality.Name, d.volunteer.locality.RegionName))
+	b.WriteString(fmt.Sprintf("%s %s\n", emojiTime, m.Localize.FormatDateTime(time.Now(), u.lang())))
+	if o := d.volunteer.organisation; o != nil {
+		b.WriteString(m.organisationText(&service.HelpOrganisation{Name: o.Name, Verified: o.Verified}, u.lang()))
+	}
+	for _, c := range d.volunteer.categories.selected() {
+		b.WriteString(fmt.Sprintf("%s %s\n", emojiItem, c.Name))
+	}
+	if len(d.volunteer.attachments) > 0 {
+		b.WriteString(fmt.Sprintf("%s %d\n", emojiAttached, len(d.volunteer.attachments)))
+	}
+	b.WriteString(m.detailsText(d.volunteer.details, u.lang()))
+	b.WriteString(fmt.Sprintf("%s\n", d.volunteer.description))
+	b.WriteString(fmt.Sprintf("%s\n", contactText(d.volunteer.contact)))
+	b.WriteString(fmt.Sprintf("%s", m.Localize.Translate(navigationHintTr, u.lang())))
 
+	go func() {
+		err := m.Service.NewHelp(context.Background(), help)
 		if err != nil {
 			m.L.Error("create new help", zap.Error(err))
 		}
 	}()
 
-	m.dialogs.delete(u.chatID())
+	m.dialogs.delete(u.dialogKey())
 	msg := tg.NewMessage(u.chatID(), b.String())
 	msg.ParseMode = "HTML"
 	msg.ReplyMarkup = tg.ReplyKeyboardHide{HideKeyboard: true}
@@ -278,78 +336,65 @@ func (m *MessageHandler) handleVolunteerDescriptionTextReply(u *Update) error {
 	return err
 }
 
-func (v *volunteer) categoryKeyboardLayout(cancelbtn, nextbtn string) [][]tg.KeyboardButton {
-	layout := make([][]tg.KeyboardButton, 0, len(v.categoryKeyboard))
-	for _, key := range v.categoryKeyboard {
-		if len(layout) == 0 || len(layout[len(layout)-1]) == 2 {
-			layout = append(layout, []tg.KeyboardButton{key.keyboardButton()})
-			continue
-		}
+func (m *MessageHandler) sendVolunteerDuplicateWarning(u *Update) error {
+	d := m.dialogs.get(u.dialogKey())
+	d.next = m.handleVolunteerDuplicateReply
 
-		layout[len(layout)-1] = append(layout[len(layout)-1], key.keyboardButton())
+	uid, err := u.userUUID()
+	if err != nil {
+		return err
 	}
 
-	if nextbtn != "" {
-		return append(layout, []tg.KeyboardButton{
-			{Text: cancelbtn},
-			{Text: nextbtn},
-		})
+	// only own help may be updated, a help of another volunteer can only be published next to it
+	var buttons []tg.KeyboardButton
+	if d.volunteer.duplicate.CreatorID == uid {
+		buttons = append(buttons, tg.KeyboardButton{Text: m.Localize.Translate(btnOptionUpdateExistingTr, u.lang())})
 	}
+	buttons = append(buttons, tg.KeyboardButton{Text: m.Localize.Translate(btnOptionPublishAnywayTr, u.lang())})
 
-	return append(layout, []tg.KeyboardButton{{Text: cancelbtn}})
-}
-
-func (v *volunteer) invertCategoryButton(msg string) (uuid.UUID, bool) {
-	for _, keyboard := range v.categoryKeyboard {
-		if ok, checked := keyboard.invert(msg); ok {
-			if checked {
-				v.categories = append(v.categories, &category{
-					uid:  keyboard.uid,
-					text: keyboard.text,
-				})
-			} else {
-				v.rmCategory(keyboard.uid)
-			}
-			return keyboard.uid, true
-		}
+	txt := fmt.Sprintf(m.Localize.Translate(volunteerDuplicateWarningTr, u.lang()), m.helpText(*d.volunteer.duplicate, u.lang()))
+	msg := tg.NewMessage(u.chatID(), txt)
+	msg.ReplyMarkup = tg.ReplyKeyboardMarkup{
+		Keyboard: [][]tg.KeyboardButton{
+			buttons,
+			{{Text: m.Localize.Translate(btnOptionCancelTr, u.lang())}},
+		},
+		ResizeKeyboard: true,
 	}
-
-	return uuid.UUID{}, false
+	_, err = m.Api.Send(msg)
+	return err
 }
 
-func (v *volunteer) rmCategory(uid uuid.UUID) {
-	for i, x := range v.categories {
-		if x.uid == uid {
-			v.categories = append(v.categories[:i], v.categories[i+1:]...)
-		}
-	}
-}
+func (m *MessageHandler) handleVolunteerDuplicateReply(u *Update) error {
+	d := m.dialogs.get(u.dialogKey())
 
-type (
-	categoryCheckbox struct {
-		category
-		checked bool
+	switch u.Message.Text {
+	case m.Localize.Translate(btnOptionPublishAnywayTr, u.lang()):
+		return m.publishVolunteerHelp(u)
+	case m.Localize.Translate(btnOptionUpdateExistingTr, u.lang()):
+	default:
+		_, err := m.Api.Send(tg.NewMessage(u.chatID(), m.Localize.Translate(errorChooseOptionTr, u.lang())))
+		return err
 	}
 
-	category struct {
-		uid  uuid.UUID
-		text string
+	uid, err := u.userUUID()
+	if err != nil {
+		return err
 	}
-)
 
-func (b *categoryCheckbox) keyboardButton() tg.KeyboardButton {
-	if b.checked {
-		return tg.KeyboardButton{Text: emojiCheckbox + " " + b.text}
+	err = m.Service.UpdateHelp(u.ctx, d.volunteer.duplicate.ID, d.volunteer.newHelp(uid))
+	if errors.Is(err, service.ErrNotFound) {
+		m.dialogs.delete(u.dialogKey())
+		_, err = m.Api.Send(tg.NewMessage(u.chatID(), m.Localize.Translate(errorHelpDoesNotExistTr, u.lang())))
+		return err
 	}
-
-	return tg.KeyboardButton{Text: b.text}
-}
-
-func (b *categoryCheckbox) invert(text string) (ok, checked bool) {
-	if strings.Contains(text, b.text) {
-		b.checked = !b.checked
-		return true, b.checked
+	if err != nil {
+		return err
 	}
 
-	return false, false
+	m.dialogs.delete(u.dialogKey())
+	msg := tg.NewMessage(u.chatID(), fmt.Sprintf("%s\n\n%s", m.Localize.Translate(volunteerHelpUpdatedTr, u.lang()), m.Localize.Translate(navigationHintTr, u.lang())))
+	msg.ReplyMarkup = tg.ReplyKeyboardHide{HideKeyboard: true}
+	_, err = m.Api.Send(msg)
+	return err
 }
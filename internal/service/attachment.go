@@ -0,0 +1,61 @@
+package service
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/rvkinc/uasocial/internal/storage"
+)
+
+// MaxHelpAttachments is the number of photos and documents a help may have.
+const MaxHelpAttachments = 5
+
+// Attachment types
+const (
+	AttachmentPhoto    = "photo"
+	AttachmentDocument = "document"
+)
+
+// Attachment is a photo or document stored by Telegram, FileID is valid for the bot only.
+type Attachment struct {
+	Type   string
+	FileID string
+}
+
+func validateAttachments(attachments []Attachment) error {
+	if len(attachments) > MaxHelpAttachments {
+		return ErrInvalidInput
+	}
+
+	for _, a := range attachments {
+		if a.FileID == "" || (a.Type != AttachmentPhoto && a.Type != AttachmentDocument) {
+			return ErrInvalidInput
+		}
+	}
+	return nil
+}
+
+func (s *Service) saveAttachments(ctx context.Context, helpID uuid.UUID, attachments []Attachment) error {
+	if len(attachments) == 0 {
+		return nil
+	}
+
+	as := make([]*storage.HelpAttachment, 0, len(attachments))
+	for _, a := range attachments {
+		as = append(as, &storage.HelpAttachment{Type: a.Type, FileID: a.FileID})
+	}
+	return s.storage.InsertHelpAttachments(ctx, helpID, as)
+}
+
+func (s *Service) helpAttachments(ctx context.Context, helpID uuid.UUID) ([]Attachment, error) {
+	as, err := s.storage.SelectHelpAttachments(ctx, helpID)
+	if err != nil {
+		return nil, err
+	}
+
+	attachments := make([]Attachment, 0, len(as))
+	for _, a := range as {
+		attachments = append(attachments, Attachment{Type: a.Type, FileID: a.FileID})
+	}
+	return attachments, nil
+}
@@ -0,0 +1,180 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rvkinc/uasocial/internal/storage"
+)
+
+const expiredPostsInterval = time.Hour
+
+type HelpEventKind int
+
+// Help event kinds
+const (
+	HelpPublished HelpEventKind = iota + 1 // help should be posted to channels
+	HelpEdited                             // channel posts should be updated
+	HelpRemoved                            // channel posts should be deleted
+)
+
+type (
+	// ChannelConfig maps a settlement, district or oblast to the channel its helps are posted to.
+	ChannelConfig struct {
+		LocalityID int   `yaml:"locality_id"`
+		ChatID     int64 `yaml:"chat_id"`
+	}
+
+	ChannelPost struct {
+		ChatID    int64
+		MessageID int
+	}
+
+	HelpEvent struct {
+		Kind HelpEventKind
+		Help UserHelp // empty for removed helps
+		Link string   // deep link to the help in the bot
+
+		ShareContact bool // contact of the help can be revealed in channels
+
+		ChatIDs []int64       // channels to post published help to
+		Posts   []ChannelPost // existing posts of edited or removed help
+	}
+)
+
+// HelpEvents receives helps to be posted, updated or deleted in channels.
+func (s *Service) HelpEvents() chan HelpEvent { return s.helpEventsCh }
+
+// SaveChannelPost remembers channel message the help was posted with.
+func (s *Service) SaveChannelPost(ctx context.Context, helpID uuid.UUID, post ChannelPost) error {
+	return s.storage.InsertHelpChannelPost(ctx, &storage.HelpChannelPost{
+		HelpID:    helpID,
+		ChatID:    post.ChatID,
+		MessageID: post.MessageID,
+	})
+}
+
+// helpChannels returns channels configured for the locality or any of its ancestors.
+func (s *Service) helpChannels(ctx context.Context, localityID int) ([]int64, error) {
+	if len(s.config.Channels) == 0 {
+		return nil, nil
+	}
+
+	ancestors, err := s.LocalityAncestors(ctx, localityID)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		chatIDs = make([]int64, 0)
+		seen    = make(map[int64]bool)
+	)
+
+	for _, c := range s.config.Channels {
+		for _, l := range ancestors {
+			if l.ID == c.LocalityID && !seen[c.ChatID] {
+				seen[c.ChatID] = true
+				chatIDs = append(chatIDs, c.ChatID)
+			}
+		}
+	}
+
+	return chatIDs, nil
+}
+
+func (s *Service) publishHelp(ctx context.Context, help UserHelp, localityID int) error {
+	chatIDs, err := s.helpChannels(ctx, localityID)
+	if err != nil || len(chatIDs) == 0 {
+		return err
+	}
+
+	share, err := s.sharesContact(ctx, help.CreatorID)
+	if err != nil {
+		return err
+	}
+
+	go s.notifyHelpEvent(HelpEvent{Kind: HelpPublished, Help: channelHelp(help, share), Link: s.HelpLink(help.ID), ChatIDs: chatIDs, ShareContact: share && help.HasContact})
+	return nil
+}
+
+// channelHelp masks contacts typed in the description unless the volunteer shares contacts, like the public feed does.
+func channelHelp(help UserHelp, share bool) UserHelp {
+	if !share {
+		help.Description = maskContacts(help.Description)
+	}
+	return help
+}
+
+// republishHelp updates channel posts of the edited help.
+func (s *Service) republishHelp(ctx context.Context, helpID uuid.UUID) error {
+	posts, err := s.channelPosts(ctx, helpID)
+	if err != nil || len(posts) == 0 {
+		return err
+	}
+
+	help, err := s.HelpByID(ctx, helpID)
+	if err != nil {
+		return err
+	}
+
+	share, err := s.sharesContact(ctx, help.CreatorID)
+	if err != nil {
+		return err
+	}
+
+	go s.notifyHelpEvent(HelpEvent{Kind: HelpEdited, Help: channelHelp(help, share), Link: s.HelpLink(help.ID), Posts: posts, ShareContact: share && help.HasContact})
+	return nil
+}
+
+// unpublishHelp deletes channel posts of the deleted or expired help.
+func (s *Service) unpublishHelp(ctx context.Context, helpID uuid.UUID) error {
+	posts, err := s.channelPosts(ctx, helpID)
+	if err != nil || len(posts) == 0 {
+		return err
+	}
+
+	err = s.storage.DeleteHelpChannelPosts(ctx, helpID)
+	if err != nil {
+		return err
+	}
+
+	go s.notifyHelpEvent(HelpEvent{Kind: HelpRemoved, Posts: posts})
+	return nil
+}
+
+// handleExpiredChannelPosts removes expired helps from channels soon after they expire,
+// expired helps themselves are collected on a much longer period.
+func (s *Service) handleExpiredChannelPosts() {
+	ticker := time.NewTicker(expiredPostsInterval).C
+	for range ticker {
+		ids, err := s.storage.SelectExpiredPostedHelps(context.Background(), time.Now().Add(-tenDaysDuration))
+		if err != nil {
+			// log here
+			continue
+		}
+
+		for _, id := range ids {
+			err = s.unpublishHelp(context.Background(), id)
+			if err != nil {
+				// log here
+				continue
+			}
+		}
+	}
+}
+
+func (s *Service) channelPosts(ctx context.Context, helpID uuid.UUID) ([]ChannelPost, error) {
+	ps, err := s.storage.SelectHelpChannelPosts(ctx, helpID)
+	if err != nil {
+		return nil, err
+	}
+
+	posts := make([]ChannelPost, 0, len(ps))
+	for _, p := range ps {
+		posts = append(posts, ChannelPost{ChatID: p.ChatID, MessageID: p.MessageID})
+	}
+	return posts, nil
+}
+
+func (s *Service) notifyHelpEvent(e HelpEvent) { s.helpEventsCh <- e }
@@ -0,0 +1,143 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/google/uuid"
+	"github.com/rvkinc/uasocial/internal/storage"
+)
+
+var (
+	e164Re     = regexp.MustCompile(`^\+[1-9]\d{7,14}$`)            // nolint:gochecknoglobals
+	usernameRe = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_]{4,31}$`) // nolint:gochecknoglobals
+)
+
+// Contact is how the volunteer can be reached, either field may be empty.
+type Contact struct {
+	Phone    string // E.164
+	Username string // telegram username without @
+}
+
+func (c Contact) Empty() bool { return c.Phone == "" && c.Username == "" }
+
+func (c Contact) validate() error {
+	if (c.Phone != "" && !e164Re.MatchString(c.Phone)) || (c.Username != "" && !usernameRe.MatchString(c.Username)) {
+		return ErrInvalidInput
+	}
+	return nil
+}
+
+func (c Contact) storage() storage.HelpContact {
+	var sc storage.HelpContact
+	if c.Phone != "" {
+		sc.ContactPhone = &c.Phone
+	}
+	if c.Username != "" {
+		sc.ContactUsername = &c.Username
+	}
+	return sc
+}
+
+func helpContact(sc storage.HelpContact) Contact {
+	var c Contact
+	if sc.ContactPhone != nil {
+		c.Phone = *sc.ContactPhone
+	}
+	if sc.ContactUsername != nil {
+		c.Username = *sc.ContactUsername
+	}
+	return c
+}
+
+// NormalizeUAPhone returns ukrainian phone number typed in any common format,
+// e.g. "067 123 45 67" or "+38 (067) 123-45-67", in E.164.
+func NormalizeUAPhone(s string) (string, error) {
+	digits, ok := phoneDigits(s)
+	if !ok {
+		return "", ErrInvalidInput
+	}
+
+	switch {
+	case len(digits) == 12 && strings.HasPrefix(digits, "380"):
+	case len(digits) == 11 && strings.HasPrefix(digits, "80"):
+		digits = "3" + digits
+	case len(digits) == 10 && strings.HasPrefix(digits, "0"):
+		digits = "38" + digits
+	case len(digits) == 9 && digits[0] != '0': // national number without trunk prefix
+		digits = "380" + digits
+	default:
+		return "", ErrInvalidInput
+	}
+
+	return "+" + digits, nil
+}
+
+// NormalizeSharedPhone returns phone of a shared telegram contact in E.164, it may be of any country and comes without plus sign.
+func NormalizeSharedPhone(s string) (string, error) {
+	digits, ok := phoneDigits(s)
+	if !ok || !e164Re.MatchString("+"+digits) {
+		return "", ErrInvalidInput
+	}
+	return "+" + digits, nil
+}
+
+// phoneDigits strips phone formatting, false is returned for anything but digits and formatting.
+func phoneDigits(s string) (string, bool) {
+	var b strings.Builder
+	for _, r := range s {
+		switch {
+		case unicode.IsDigit(r):
+			b.WriteRune(r)
+		case strings.ContainsRune(" ()-.+", r):
+		default:
+			return "", false
+		}
+	}
+	return b.String(), true
+}
+
+// HelpContact returns contact of the help. Outside the bot, e.g. in channels,
+// the contact is revealed only if the volunteer shares contacts.
+// The user who asked is asked for feedback on the help later.
+func (s *Service) HelpContact(ctx context.Context, helpID, uid uuid.UUID, outside bool) (Contact, error) {
+	help, err := s.storage.SelectHelpByID(ctx, helpID)
+	if errors.Is(err, storage.ErrNotFound) {
+		return Contact{}, ErrNotFound
+	}
+	if err != nil {
+		return Contact{}, err
+	}
+
+	// helps awaiting moderation or rejected don't reveal contacts
+	c := helpContact(help.HelpContact)
+	if help.DeletedAt != nil || help.Status != HelpStatusActive || c.Empty() {
+		return Contact{}, ErrNotFound
+	}
+
+	if outside {
+		share, err := s.sharesContact(ctx, help.CreatorID)
+		if err != nil {
+			return Contact{}, err
+		}
+
+		if !share {
+			return Contact{}, ErrNotFound
+		}
+	}
+
+	err = s.rememberContact(ctx, help, uid)
+	if err != nil {
+		return Contact{}, err
+	}
+
+	return c, nil
+}
+
+func (s *Service) sharesContact(ctx context.Context, uid uuid.UUID) (bool, error) {
+	p, err := s.Preferences(ctx, uid)
+	return p.ShareContact, err
+}
@@ -0,0 +1,74 @@
+package service
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNormalizeUAPhone(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    string
+		wantErr bool
+	}{
+		{in: "+380671234567", want: "+380671234567"},
+		{in: "380671234567", want: "+380671234567"},
+		{in: "+38 (067) 123-45-67", want: "+380671234567"},
+		{in: "067 123 45 67", want: "+380671234567"},
+		{in: "067.123.45.67", want: "+380671234567"},
+		{in: "8 067 123 45 67", want: "+380671234567"},
+		{in: "671234567", want: "+380671234567"},
+		{in: "", wantErr: true},
+		{in: "12345", wantErr: true},
+		{in: "067 123 45 6", wantErr: true},
+		{in: "0671234567 1", wantErr: true},
+		{in: "+48 612 345 678", wantErr: true},
+		{in: "+1 212 555 0100", wantErr: true},
+		{in: "067-123-45-67 ext", wantErr: true},
+		{in: "067/123/45/67", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := NormalizeUAPhone(tt.in)
+		if tt.wantErr {
+			if !errors.Is(err, ErrInvalidInput) {
+				t.Errorf("NormalizeUAPhone(%q) = %q, %v, want ErrInvalidInput", tt.in, got, err)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("NormalizeUAPhone(%q) = %q, %v, want %q", tt.in, got, err, tt.want)
+		}
+	}
+}
+
+func TestNormalizeSharedPhone(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    string
+		wantErr bool
+	}{
+		{in: "380671234567", want: "+380671234567"},
+		{in: "+380671234567", want: "+380671234567"},
+		{in: "48612345678", want: "+48612345678"},
+		{in: "12125550100", want: "+12125550100"},
+		{in: "", wantErr: true},
+		{in: "0671234567", wantErr: true},
+		{in: "1234567", wantErr: true},
+		{in: "3806712345678901", wantErr: true},
+		{in: "38067123456x", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := NormalizeSharedPhone(tt.in)
+		if tt.wantErr {
+			if !errors.Is(err, ErrInvalidInput) {
+				t.Errorf("NormalizeSharedPhone(%q) = %q, %v, want ErrInvalidInput", tt.in, got, err)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("NormalizeSharedPhone(%q) = %q, %v, want %q", tt.in, got, err, tt.want)
+		}
+	}
+}
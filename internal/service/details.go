@@ -0,0 +1,121 @@
+package service
+
+import (
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/rvkinc/uasocial/internal/storage"
+)
+
+// Capacity units
+const (
+	CapacitySeats = "SEATS"
+	CapacityBeds  = "BEDS"
+	CapacityKg    = "KG"
+
+	MaxCapacity = 100000
+)
+
+// HelpLanguages are languages a volunteer may speak.
+var HelpLanguages = []string{"UA", "RU", "EN", "PL", "DE"} // nolint:gochecknoglobals
+
+// HelpDetails are optional structured fields of a help, zero values are not set.
+type HelpDetails struct {
+	Capacity     int
+	CapacityUnit string
+
+	// AvailableFrom and AvailableTo are dates, either end of the range may be open
+	AvailableFrom *time.Time
+	AvailableTo   *time.Time
+	Weekdays      []time.Weekday // any day when empty
+
+	// HoursFrom and HoursTo are hours in Kyiv time, the window passes midnight when HoursFrom > HoursTo
+	HoursFrom *int
+	HoursTo   *int
+
+	Languages []string
+}
+
+func (d HelpDetails) validate() error {
+	switch {
+	case d.Capacity < 0 || d.Capacity > MaxCapacity:
+		return ErrInvalidInput
+	case d.Capacity > 0 && d.CapacityUnit != CapacitySeats && d.CapacityUnit != CapacityBeds && d.CapacityUnit != CapacityKg:
+		return ErrInvalidInput
+	case d.AvailableFrom != nil && d.AvailableTo != nil && d.AvailableTo.Before(*d.AvailableFrom):
+		return ErrInvalidInput
+	case (d.HoursFrom == nil) != (d.HoursTo == nil):
+		return ErrInvalidInput
+	case d.HoursFrom != nil && (!validHour(*d.HoursFrom) || !validHour(*d.HoursTo) || *d.HoursFrom == *d.HoursTo):
+		return ErrInvalidInput
+	}
+
+	for _, wd := range d.Weekdays {
+		if wd < time.Sunday || wd > time.Saturday {
+			return ErrInvalidInput
+		}
+	}
+
+	for _, l := range d.Languages {
+		if !isHelpLanguage(l) {
+			return ErrInvalidInput
+		}
+	}
+
+	return nil
+}
+
+func isHelpLanguage(lang string) bool {
+	for _, l := range HelpLanguages {
+		if l == lang {
+			return true
+		}
+	}
+	return false
+}
+
+func (d HelpDetails) storage() storage.HelpDetails {
+	var sd = storage.HelpDetails{
+		AvailableFrom: d.AvailableFrom,
+		AvailableTo:   d.AvailableTo,
+		HoursFrom:     d.HoursFrom,
+		HoursTo:       d.HoursTo,
+	}
+
+	if d.Capacity > 0 {
+		sd.Capacity, sd.CapacityUnit = &d.Capacity, &d.CapacityUnit
+	}
+
+	if len(d.Weekdays) > 0 {
+		sd.Weekdays = make(pq.Int64Array, 0, len(d.Weekdays))
+		for _, wd := range d.Weekdays {
+			sd.Weekdays = append(sd.Weekdays, int64(wd))
+		}
+	}
+
+	if len(d.Languages) > 0 {
+		sd.Languages = d.Languages
+	}
+
+	return sd
+}
+
+func helpDetails(sd storage.HelpDetails) HelpDetails {
+	var d = HelpDetails{
+		AvailableFrom: sd.AvailableFrom,
+		AvailableTo:   sd.AvailableTo,
+		HoursFrom:     sd.HoursFrom,
+		HoursTo:       sd.HoursTo,
+		Languages:     sd.Languages,
+	}
+
+	if sd.Capacity != nil && sd.CapacityUnit != nil {
+		d.Capacity, d.CapacityUnit = *sd.Capacity, *sd.CapacityUnit
+	}
+
+	for _, wd := range sd.Weekdays {
+		d.Weekdays = append(d.Weekdays, time.Weekday(wd))
+	}
+
+	return d
+}
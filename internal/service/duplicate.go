@@ -0,0 +1,136 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"regexp"
+
+	"github.com/google/uuid"
+	"github.com/rvkinc/uasocial/internal/storage"
+)
+
+// duplicateSimilarity is the minimal trigram similarity of normalized descriptions to consider helps duplicates.
+const duplicateSimilarity = 0.6
+
+var phoneRe = regexp.MustCompile(`\+?\d[\d\s().\-]{7,}\d`) // nolint:gochecknoglobals
+
+// helpPhones returns normalized phones of the contact and the ones typed in the description.
+func helpPhones(help NewHelp) []string {
+	var (
+		seen   = make(map[string]bool)
+		phones []string
+	)
+
+	if help.Contact.Phone != "" {
+		seen[help.Contact.Phone] = true
+		phones = append(phones, help.Contact.Phone)
+	}
+
+	for _, s := range phoneRe.FindAllString(help.Description, -1) {
+		p, err := NormalizeUAPhone(s)
+		if err != nil || seen[p] {
+			continue
+		}
+		seen[p] = true
+		phones = append(phones, p)
+	}
+	return phones
+}
+
+func (s *Service) duplicateHelp(ctx context.Context, help NewHelp, excludeID uuid.UUID) (*storage.DuplicateHelp, error) {
+	dup, err := s.storage.SelectDuplicateHelp(ctx, storage.DuplicateFilter{
+		LocalityID:  help.LocalityID,
+		Description: help.Description,
+		Phones:      helpPhones(help),
+		CategoryIDs: help.CategoryIDs,
+		Similarity:  duplicateSimilarity,
+		ExcludeID:   excludeID,
+	})
+	if errors.Is(err, storage.ErrNotFound) {
+		return nil, ErrNotFound
+	}
+	return dup, err
+}
+
+// FindDuplicateHelp returns active help the new one is a near-duplicate of: same locality and
+// similar description or same phone, ErrNotFound is returned if there is none.
+func (s *Service) FindDuplicateHelp(ctx context.Context, help NewHelp) (UserHelp, error) {
+	dup, err := s.duplicateHelp(ctx, help, uuid.Nil)
+	if err != nil {
+		return UserHelp{}, err
+	}
+	return s.HelpByID(ctx, dup.ID)
+}
+
+// UpdateHelp replaces description, details and contact of the creator's help, attachments are replaced if any given.
+func (s *Service) UpdateHelp(ctx context.Context, helpID uuid.UUID, help NewHelp) error {
+	err := validateAttachments(help.Attachments)
+	if err != nil {
+		return err
+	}
+
+	err = help.Details.validate()
+	if err != nil {
+		return err
+	}
+
+	err = help.Contact.validate()
+	if err != nil {
+		return err
+	}
+
+	existing, err := s.HelpByID(ctx, helpID)
+	if err != nil {
+		return err
+	}
+
+	if existing.CreatorID != help.CreatorID {
+		return ErrNotFound
+	}
+
+	score, reasons, err := s.checkContent(ctx, help)
+	if err != nil {
+		return err
+	}
+
+	status := HelpStatusActive
+	if score >= SuspiciousScore {
+		status = HelpStatusPending
+	}
+
+	err = s.storage.UpdateHelp(ctx, helpID, &storage.HelpUpdate{
+		Description: help.Description,
+		Phones:      helpPhones(help),
+		HelpDetails: help.Details.storage(),
+		HelpContact: help.Contact.storage(),
+		HelpModeration: storage.HelpModeration{
+			Status:      status,
+			SpamScore:   score,
+			SpamReasons: reasons,
+		},
+	})
+	if errors.Is(err, storage.ErrNotFound) {
+		return ErrNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	if len(help.Attachments) > 0 {
+		err = s.storage.DeleteHelpAttachments(ctx, helpID)
+		if err != nil {
+			return err
+		}
+
+		err = s.saveAttachments(ctx, helpID, help.Attachments)
+		if err != nil {
+			return err
+		}
+	}
+
+	if status == HelpStatusPending {
+		return s.holdHelp(ctx, helpID, score, reasons)
+	}
+
+	return s.republishHelp(ctx, helpID)
+}
@@ -0,0 +1,46 @@
+package service
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestHelpPhones(t *testing.T) {
+	tests := []struct {
+		name string
+		help NewHelp
+		want []string
+	}{
+		{
+			name: "none",
+			help: NewHelp{Description: "Free hot meals every day at 12:00, 2022-04-15"},
+		},
+		{
+			name: "contact only",
+			help: NewHelp{Contact: Contact{Phone: "+380671234567"}, Description: "Free hot meals"},
+			want: []string{"+380671234567"},
+		},
+		{
+			name: "description formats",
+			help: NewHelp{Description: "Call +38 (067) 123-45-67 or 050.765.43.21"},
+			want: []string{"+380671234567", "+380507654321"},
+		},
+		{
+			name: "contact repeated in description",
+			help: NewHelp{Contact: Contact{Phone: "+380671234567"}, Description: "Call 067 123 45 67, evenings 0931112233"},
+			want: []string{"+380671234567", "+380931112233"},
+		},
+		{
+			name: "foreign and card numbers skipped",
+			help: NewHelp{Description: "Donate 4111 1111 1111 1111, Poland +48 612 345 678"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := helpPhones(tt.help); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("helpPhones() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,119 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/rvkinc/uasocial/internal/storage"
+)
+
+const maskedContact = "***"
+
+// nolint:gochecknoglobals
+var (
+	emailRe        = regexp.MustCompile(`[\w.%+\-]+@[\w\-]+(\.[\w\-]+)*\.[A-Za-z]{2,}`)
+	telegramLinkRe = regexp.MustCompile(`(?i)\b(https?://)?(www\.)?(t|telegram)\.me/[\w+/\-]+`)
+	mentionRe      = regexp.MustCompile(`@[A-Za-z]\w{4,31}`) // telegram usernames have at least 5 characters
+)
+
+type (
+	// PublicHelp is an active help shown outside Telegram,
+	// the contact is set only if the volunteer shares contacts, otherwise contacts in the description are masked.
+	PublicHelp struct {
+		UserHelp
+		Contact Contact
+	}
+
+	// PublicHelpPage is a page of public helps, Next is nil on the last page.
+	PublicHelpPage struct {
+		Helps []PublicHelp
+		Next  *Cursor
+	}
+)
+
+// PublicHelp returns active help with the contact if the volunteer shares contacts.
+func (s *Service) PublicHelp(ctx context.Context, helpID uuid.UUID) (PublicHelp, error) {
+	help, err := s.storage.SelectHelpByID(ctx, helpID)
+	if errors.Is(err, storage.ErrNotFound) {
+		return PublicHelp{}, ErrNotFound
+	}
+	if err != nil {
+		return PublicHelp{}, err
+	}
+
+	if help.DeletedAt != nil || help.Status != HelpStatusActive {
+		return PublicHelp{}, ErrNotFound
+	}
+
+	h, err := s.userHelp(ctx, help)
+	if err != nil {
+		return PublicHelp{}, err
+	}
+	return publicHelp(h, help), nil
+}
+
+// PublicFeed returns a page of the newest active helps in the region, the whole country if it is zero,
+// in any of the categories or their subcategories, all categories if none are given.
+func (s *Service) PublicFeed(ctx context.Context, regionID int, categoryIDs []uuid.UUID, after *Cursor, limit int) (PublicHelpPage, error) {
+	if regionID == 0 {
+		id, err := s.storage.SelectCountryLocalityID(ctx)
+		if err != nil {
+			return PublicHelpPage{}, err
+		}
+		regionID = id
+	}
+
+	if len(categoryIDs) == 0 {
+		categories, err := s.GetCategories(ctx)
+		if err != nil {
+			return PublicHelpPage{}, err
+		}
+		for _, c := range categories {
+			categoryIDs = append(categoryIDs, c.ID)
+		}
+	}
+
+	hs, err := s.helpsByCategoryLocation(ctx, HelpQuery{LocalityID: regionID, CategoryIDs: categoryIDs, Sort: SortNewest}, after, limit)
+	if err != nil {
+		return PublicHelpPage{}, err
+	}
+
+	// helps of the page keep the order of the selected ones
+	page := helpPage(hs, limit)
+	result := PublicHelpPage{Helps: make([]PublicHelp, 0, len(page.Helps)), Next: page.Next}
+	for i, h := range page.Helps {
+		result.Helps = append(result.Helps, publicHelp(h, hs[i]))
+	}
+	return result, nil
+}
+
+// publicHelp sets the contact of the help if its creator shares contacts and masks contacts in the description otherwise.
+func publicHelp(h UserHelp, help *storage.Help) PublicHelp {
+	if help.ContactShared {
+		return PublicHelp{UserHelp: h, Contact: helpContact(help.HelpContact)}
+	}
+
+	h.Description = maskContacts(h.Description)
+	return PublicHelp{UserHelp: h}
+}
+
+// maskContacts hides contacts typed in the text: e-mails, telegram links and usernames, the phones helpPhones finds
+// and international ones, which start with the plus sign unlike dates and the like.
+func maskContacts(text string) string {
+	text = emailRe.ReplaceAllString(text, maskedContact)
+	text = telegramLinkRe.ReplaceAllString(text, maskedContact)
+	text = mentionRe.ReplaceAllString(text, maskedContact)
+
+	return phoneRe.ReplaceAllStringFunc(text, func(s string) string {
+		if _, err := NormalizeUAPhone(s); err == nil {
+			return maskedContact
+		}
+		if _, err := NormalizeSharedPhone(s); err == nil && strings.HasPrefix(s, "+") {
+			return maskedContact
+		}
+		return s
+	})
+}
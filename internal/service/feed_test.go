@@ -0,0 +1,28 @@
+package service
+
+import "testing"
+
+func TestMaskContacts(t *testing.T) {
+	tests := []struct {
+		text string
+		want string
+	}{
+		{"Call +38 (067) 123-45-67 after 18:00", "Call *** after 18:00"},
+		{"067 123 45 67 or 050.765.43.21", "*** or ***"},
+		{"Poland +48 612 345 678", "Poland ***"},
+		{"Write @food_bank_kyiv or @Olena", "Write *** or ***"},
+		{"Chat t.me/food_bank, https://t.me/+AbC-123 or telegram.me/joinchat/xyz", "Chat ***, *** or ***"},
+		{"Mail olena.k+help@food-bank.org.ua!", "Mail ***!"},
+		{"@ 12:00, me@home", "@ 12:00, me@home"},
+		{"Visit chat.me/room", "Visit chat.me/room"},
+		{"Open 2022-04-15 till 2022-05-01", "Open 2022-04-15 till 2022-05-01"},
+		{"Donate 4111 1111 1111 1111", "Donate 4111 1111 1111 1111"},
+		{"Free hot meals every day", "Free hot meals every day"},
+	}
+
+	for _, tt := range tests {
+		if got := maskContacts(tt.text); got != tt.want {
+			t.Errorf("maskContacts(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
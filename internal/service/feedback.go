@@ -0,0 +1,105 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rvkinc/uasocial/internal/storage"
+)
+
+// Feedback answers
+const (
+	FeedbackYes    = "YES"
+	FeedbackNo     = "NO"
+	FeedbackReport = "REPORT"
+)
+
+const (
+	feedbackDelay         = time.Hour * 24 * 3 // seekers are asked a few days after they opened the contact
+	feedbackQueueInterval = time.Hour
+	feedbackReportReason  = "reported by seeker"
+)
+
+type (
+	// FeedbackRequest asks the seeker whether they received the help.
+	FeedbackRequest struct {
+		HelpID      uuid.UUID
+		ChatID      int64
+		Language    string
+		Description string
+	}
+
+	// Reputation counts feedback of seekers on helps of a volunteer.
+	Reputation struct {
+		Positive int
+		Negative int
+	}
+)
+
+// FeedbackRequests receives seekers to be asked for feedback.
+func (s *Service) FeedbackRequests() chan FeedbackRequest { return s.feedbackRequestsCh }
+
+func (s *Service) handleFeedbackQueue() {
+	ticker := time.NewTicker(feedbackQueueInterval).C
+	for range ticker {
+		requests, err := s.storage.UpdateFeedbackAsked(context.Background(), time.Now().Add(-feedbackDelay))
+		if err != nil {
+			// log here
+			continue
+		}
+
+		for _, r := range requests {
+			s.feedbackRequestsCh <- FeedbackRequest{
+				HelpID:      r.HelpID,
+				ChatID:      r.ChatID,
+				Language:    r.Language,
+				Description: r.Description,
+			}
+		}
+	}
+}
+
+// rememberContact schedules feedback request to the seeker who opened the contact,
+// volunteers aren't asked about their own helps.
+func (s *Service) rememberContact(ctx context.Context, help *storage.Help, uid uuid.UUID) error {
+	if help.CreatorID == uid {
+		return nil
+	}
+	return s.storage.InsertHelpFeedback(ctx, help.ID, uid)
+}
+
+// LeaveFeedback saves the answer of the seeker to the reputation of the volunteer,
+// reported helps are held for moderation. ErrNotFound is returned if the seeker has already answered.
+func (s *Service) LeaveFeedback(ctx context.Context, helpID, uid uuid.UUID, answer string) error {
+	if answer != FeedbackYes && answer != FeedbackNo && answer != FeedbackReport {
+		return ErrInvalidInput
+	}
+
+	err := s.storage.UpdateFeedbackAnswer(ctx, helpID, uid, answer)
+	if errors.Is(err, storage.ErrNotFound) {
+		return ErrNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	if answer != FeedbackReport {
+		return nil
+	}
+
+	err = s.storage.HoldHelp(ctx, helpID)
+	if errors.Is(err, storage.ErrNotFound) {
+		return nil // already held or deleted
+	}
+	if err != nil {
+		return err
+	}
+
+	return s.holdHelp(ctx, helpID, SuspiciousScore, []string{feedbackReportReason})
+}
+
+func helpReputation(r storage.HelpReputation) Reputation {
+	return Reputation{Positive: r.ReputationPositive, Negative: r.ReputationNegative}
+}
@@ -0,0 +1,129 @@
+package service
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// Deep link kinds
+const (
+	LinkHelp      = "help"
+	LinkSearch    = "search"
+	LinkSubscribe = "subscribe"
+)
+
+const (
+	linkVersion = 1
+
+	linkKindHelp      byte = 1
+	linkKindSearch    byte = 2
+	linkKindSubscribe byte = 3
+
+	linkSignatureLength = 6
+
+	// telegram start parameter is limited to 64 base64url characters (48 bytes),
+	// version, kind, locality and signature leave room for two categories
+	maxLinkCategories = 2
+)
+
+type (
+	// DeepLink is a decoded /start payload, either HelpID or LocalityID and CategoryIDs are set depending on Kind.
+	DeepLink struct {
+		Kind        string
+		HelpID      uuid.UUID
+		LocalityID  int
+		CategoryIDs []uuid.UUID
+	}
+)
+
+// HelpLink returns link that opens the help in the bot.
+func (s *Service) HelpLink(helpID uuid.UUID) string {
+	return s.link(append([]byte{linkVersion, linkKindHelp}, helpID[:]...))
+}
+
+// SearchLink returns link that searches helps in the locality and categories.
+func (s *Service) SearchLink(localityID int, cids []uuid.UUID) (string, error) {
+	return s.localityLink(linkKindSearch, localityID, cids)
+}
+
+// SubscriptionLink returns link that subscribes user on the locality and categories in one tap.
+func (s *Service) SubscriptionLink(localityID int, cids []uuid.UUID) (string, error) {
+	return s.localityLink(linkKindSubscribe, localityID, cids)
+}
+
+func (s *Service) localityLink(kind byte, localityID int, cids []uuid.UUID) (string, error) {
+	if len(cids) == 0 || len(cids) > maxLinkCategories {
+		return "", ErrInvalidInput
+	}
+
+	body := make([]byte, 6, 6+len(cids)*16)
+	body[0], body[1] = linkVersion, kind
+	binary.BigEndian.PutUint32(body[2:], uint32(localityID))
+	for _, cid := range cids {
+		body = append(body, cid[:]...)
+	}
+
+	return s.link(body), nil
+}
+
+func (s *Service) link(body []byte) string {
+	payload := base64.RawURLEncoding.EncodeToString(append(body, s.sign(body)...))
+	return fmt.Sprintf("https://t.me/%s?start=%s", s.config.BotUsername, payload)
+}
+
+func (s *Service) sign(body []byte) []byte {
+	mac := hmac.New(sha256.New, []byte(s.config.LinkSecret))
+	mac.Write(body) // nolint:errcheck
+	return mac.Sum(nil)[:linkSignatureLength]
+}
+
+// ParseStartPayload decodes and verifies /start payload of a deep link.
+// Without the link secret signatures can be forged, so every payload is rejected.
+func (s *Service) ParseStartPayload(payload string) (DeepLink, error) {
+	if s.config.LinkSecret == "" {
+		return DeepLink{}, ErrInvalidInput
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(payload)
+	if err != nil || len(data) < 2+linkSignatureLength {
+		return DeepLink{}, ErrInvalidInput
+	}
+
+	body, sig := data[:len(data)-linkSignatureLength], data[len(data)-linkSignatureLength:]
+	if !hmac.Equal(sig, s.sign(body)) || body[0] != linkVersion {
+		return DeepLink{}, ErrInvalidInput
+	}
+
+	switch body[1] {
+	case linkKindHelp:
+		hid, err := uuid.FromBytes(body[2:])
+		if err != nil {
+			return DeepLink{}, ErrInvalidInput
+		}
+		return DeepLink{Kind: LinkHelp, HelpID: hid}, nil
+
+	case linkKindSearch, linkKindSubscribe:
+		if len(body) < 6 || (len(body)-6)%16 != 0 {
+			return DeepLink{}, ErrInvalidInput
+		}
+
+		link := DeepLink{Kind: LinkSearch, LocalityID: int(binary.BigEndian.Uint32(body[2:6]))}
+		if body[1] == linkKindSubscribe {
+			link.Kind = LinkSubscribe
+		}
+
+		for b := body[6:]; len(b) > 0; b = b[16:] {
+			cid, _ := uuid.FromBytes(b[:16])
+			link.CategoryIDs = append(link.CategoryIDs, cid)
+		}
+
+		return link, nil
+	}
+
+	return DeepLink{}, ErrInvalidInput
+}
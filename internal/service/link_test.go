@@ -0,0 +1,92 @@
+package service
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func linkPayload(t *testing.T, link string) string {
+	t.Helper()
+
+	i := strings.Index(link, "?start=")
+	if i < 0 {
+		t.Fatalf("no start payload in %s", link)
+	}
+	return link[i+len("?start="):]
+}
+
+func TestStartPayloadRoundTrip(t *testing.T) {
+	s := &Service{config: &Config{LinkSecret: "secret", BotUsername: "bot"}}
+	hid, cids := uuid.New(), []uuid.UUID{uuid.New(), uuid.New()}
+
+	search, err := s.SearchLink(42, cids)
+	if err != nil {
+		t.Fatal(err)
+	}
+	subscribe, err := s.SubscriptionLink(42, cids[:1])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		link string
+		want DeepLink
+	}{
+		{"help", s.HelpLink(hid), DeepLink{Kind: LinkHelp, HelpID: hid}},
+		{"search", search, DeepLink{Kind: LinkSearch, LocalityID: 42, CategoryIDs: cids}},
+		{"subscribe", subscribe, DeepLink{Kind: LinkSubscribe, LocalityID: 42, CategoryIDs: cids[:1]}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := s.ParseStartPayload(linkPayload(t, tt.link))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStartPayloadRejected(t *testing.T) {
+	s := &Service{config: &Config{LinkSecret: "secret", BotUsername: "bot"}}
+	payload := linkPayload(t, s.HelpLink(uuid.New()))
+
+	// flip a character of the help id, the signature no longer matches
+	tampered := []byte(payload)
+	if tampered[5] == 'A' {
+		tampered[5] = 'B'
+	} else {
+		tampered[5] = 'A'
+	}
+
+	other := &Service{config: &Config{LinkSecret: "other", BotUsername: "bot"}}
+	noSecret := &Service{config: &Config{BotUsername: "bot"}}
+
+	tests := []struct {
+		name    string
+		s       *Service
+		payload string
+	}{
+		{"tampered", s, string(tampered)},
+		{"signed with another secret", other, payload},
+		{"no secret", noSecret, linkPayload(t, noSecret.HelpLink(uuid.New()))},
+		{"not base64", s, "!!!"},
+		{"too short", s, "AQE"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := tt.s.ParseStartPayload(tt.payload); !errors.Is(err, ErrInvalidInput) {
+				t.Errorf("got %v, want %v", err, ErrInvalidInput)
+			}
+		})
+	}
+}
@@ -0,0 +1,329 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rvkinc/uasocial/internal/storage"
+)
+
+// Help statuses
+const (
+	HelpStatusActive   = "ACTIVE"
+	HelpStatusPending  = "PENDING" // held for moderation, not shown to seekers
+	HelpStatusRejected = "REJECTED"
+)
+
+// SuspiciousScore is the content check score new helps are held for moderation from.
+const SuspiciousScore = 60
+
+const (
+	maxHelpURLs        = 2
+	maxHelpsPerHour    = 3
+	pendingHelpsPerRun = 10
+)
+
+type ModerationEventKind int
+
+// Moderation event kinds
+const (
+	HelpHeld     ModerationEventKind = iota + 1 // moderators should approve or reject the help
+	HelpApproved                                // volunteer should be told the help is published
+	HelpRejected                                // volunteer should be told the help is rejected
+)
+
+type (
+	// ContentRule scores new help, zero score means the rule didn't match.
+	ContentRule interface {
+		Check(ctx context.Context, help NewHelp) (score int, reason string, err error)
+	}
+
+	// ContentRuleFunc is a function used as ContentRule.
+	ContentRuleFunc func(ctx context.Context, help NewHelp) (int, string, error)
+
+	ModerationEvent struct {
+		Kind     ModerationEventKind
+		Help     UserHelp
+		Score    int
+		Reasons  []string
+		ChatID   int64 // volunteer's chat
+		Language string
+	}
+)
+
+func (f ContentRuleFunc) Check(ctx context.Context, help NewHelp) (int, string, error) {
+	return f(ctx, help)
+}
+
+var (
+	cardNumberRe = regexp.MustCompile(`\d(?:[ -]?\d){12,18}`)                // nolint:gochecknoglobals
+	urlRe        = regexp.MustCompile(`(?i)\b(?:https?://|www\.|t\.me/)\S+`) // nolint:gochecknoglobals
+
+	// payment services scammers ask to "pay for delivery" with
+	paymentHosts = []string{ // nolint:gochecknoglobals
+		"send.monobank.ua", "monobank.ua/jar", "privat24.ua", "next.privat24.ua", "paypal.me", "paypal.com",
+		"revolut.me", "wise.com/pay", "easypay.ua", "portmone.com", "liqpay.ua", "ipay.ua", "donatello.to",
+		"buymeacoffee.com", "pay.google.com",
+	}
+)
+
+// ModerationEvents receives helps held for moderation and moderation results.
+func (s *Service) ModerationEvents() chan ModerationEvent { return s.moderationEventsCh }
+
+func (s *Service) notifyModerationEvent(e ModerationEvent) { s.moderationEventsCh <- e }
+
+// AddContentRule adds a rule to the checks new helps pass before publishing.
+func (s *Service) AddContentRule(r ContentRule) {
+	s.contentRules = append(s.contentRules, r)
+}
+
+func (s *Service) defaultContentRules() []ContentRule {
+	return []ContentRule{
+		ContentRuleFunc(cardNumberRule),
+		ContentRuleFunc(paymentLinkRule),
+		ContentRuleFunc(urlCountRule),
+		ContentRuleFunc(s.bannedPhraseRule),
+		ContentRuleFunc(s.velocityRule),
+	}
+}
+
+// checkContent sums scores of all content rules.
+func (s *Service) checkContent(ctx context.Context, help NewHelp) (int, []string, error) {
+	var (
+		total   int
+		reasons []string
+	)
+
+	for _, r := range s.contentRules {
+		score, reason, err := r.Check(ctx, help)
+		if err != nil {
+			return 0, nil, err
+		}
+
+		if score > 0 {
+			total += score
+			reasons = append(reasons, reason)
+		}
+	}
+	return total, reasons, nil
+}
+
+// cardNumberRule matches payment card numbers, validated with the Luhn checksum so phones aren't matched.
+func cardNumberRule(_ context.Context, help NewHelp) (int, string, error) {
+	for _, s := range cardNumberRe.FindAllString(help.Description, -1) {
+		digits := strings.NewReplacer(" ", "", "-", "").Replace(s)
+		if len(digits) >= 13 && luhn(digits) {
+			return 100, "card number", nil
+		}
+	}
+	return 0, "", nil
+}
+
+func luhn(digits string) bool {
+	var sum int
+	for i := len(digits) - 1; i >= 0; i-- {
+		d := int(digits[i] - '0')
+		if (len(digits)-i)%2 == 0 {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+	}
+	return sum%10 == 0
+}
+
+func paymentLinkRule(_ context.Context, help NewHelp) (int, string, error) {
+	description := strings.ToLower(help.Description)
+	for _, h := range paymentHosts {
+		if strings.Contains(description, h) {
+			return 100, "payment link " + h, nil
+		}
+	}
+	return 0, "", nil
+}
+
+func urlCountRule(_ context.Context, help NewHelp) (int, string, error) {
+	if n := len(urlRe.FindAllString(help.Description, -1)); n > maxHelpURLs {
+		return 40, fmt.Sprintf("%d links", n), nil
+	}
+	return 0, "", nil
+}
+
+func (s *Service) bannedPhraseRule(ctx context.Context, help NewHelp) (int, string, error) {
+	phrases, err := s.storage.SelectBannedPhrases(ctx)
+	if err != nil {
+		return 0, "", err
+	}
+
+	description := strings.ToLower(help.Description)
+	for _, p := range phrases {
+		if strings.Contains(description, p.Phrase) {
+			return 60, "banned phrase " + p.Phrase, nil
+		}
+	}
+	return 0, "", nil
+}
+
+func (s *Service) velocityRule(ctx context.Context, help NewHelp) (int, string, error) {
+	count, err := s.storage.SelectHelpsCountByUserSince(ctx, help.CreatorID, time.Now().Add(-time.Hour))
+	if err != nil {
+		return 0, "", err
+	}
+
+	if count >= maxHelpsPerHour {
+		return 50, fmt.Sprintf("%d helps in an hour", count+1), nil
+	}
+	return 0, "", nil
+}
+
+// PendingHelps returns the oldest helps held for moderation.
+func (s *Service) PendingHelps(ctx context.Context) ([]ModerationEvent, error) {
+	hs, err := s.storage.SelectPendingHelps(ctx, pendingHelpsPerRun)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]ModerationEvent, 0, len(hs))
+	for _, help := range hs {
+		h := UserHelp{
+			ID:               help.ID,
+			CreatorID:        help.CreatorID,
+			Description:      help.Description,
+			CreatedAt:        help.CreatedAt,
+			AttachmentsCount: help.AttachmentsCount,
+			Organisation:     helpOrganisation(help.HelpOrganisation),
+		}
+		h.localize(help)
+		events = append(events, ModerationEvent{Kind: HelpHeld, Help: h, Score: help.SpamScore, Reasons: help.SpamReasons})
+	}
+	return events, nil
+}
+
+// ApproveHelp publishes help held for moderation, ErrNotFound is returned if it isn't pending anymore.
+func (s *Service) ApproveHelp(ctx context.Context, helpID uuid.UUID) error {
+	err := s.moderateHelp(ctx, helpID, HelpStatusActive)
+	if err != nil {
+		return err
+	}
+
+	help, err := s.storage.SelectHelpByID(ctx, helpID)
+	if err != nil {
+		return err
+	}
+
+	h, err := s.userHelp(ctx, help)
+	if err != nil {
+		return err
+	}
+
+	// duplicates stay hidden behind the original
+	if help.DuplicateOf == nil {
+		err = s.announceHelp(ctx, h, help.LocalityID, help.CategoryIDs)
+		if err != nil {
+			return err
+		}
+	}
+
+	go s.notifyModerationEvent(ModerationEvent{Kind: HelpApproved, Help: h, ChatID: help.CreatorChatID, Language: help.Language})
+	return nil
+}
+
+// RejectHelp deletes help held for moderation, ErrNotFound is returned if it isn't pending anymore.
+func (s *Service) RejectHelp(ctx context.Context, helpID uuid.UUID) error {
+	err := s.storage.RejectHelp(ctx, helpID)
+	if errors.Is(err, storage.ErrNotFound) {
+		return ErrNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	help, err := s.storage.SelectHelpByID(ctx, helpID)
+	if err != nil {
+		return err
+	}
+
+	h := UserHelp{ID: help.ID, CreatorID: help.CreatorID, Description: help.Description, CreatedAt: help.CreatedAt}
+	h.localize(help)
+
+	go s.notifyModerationEvent(ModerationEvent{Kind: HelpRejected, Help: h, ChatID: help.CreatorChatID, Language: help.Language})
+	return nil
+}
+
+// holdHelp removes pending help from channels and sends it to moderators.
+func (s *Service) holdHelp(ctx context.Context, helpID uuid.UUID, score int, reasons []string) error {
+	err := s.unpublishHelp(ctx, helpID)
+	if err != nil {
+		return err
+	}
+
+	help, err := s.storage.SelectHelpByID(ctx, helpID)
+	if err != nil {
+		return err
+	}
+
+	h, err := s.userHelp(ctx, help)
+	if err != nil {
+		return err
+	}
+
+	go s.notifyModerationEvent(ModerationEvent{
+		Kind:     HelpHeld,
+		Help:     h,
+		Score:    score,
+		Reasons:  reasons,
+		ChatID:   help.CreatorChatID,
+		Language: help.Language,
+	})
+	return nil
+}
+
+func (s *Service) moderateHelp(ctx context.Context, helpID uuid.UUID, status string) error {
+	err := s.storage.UpdateHelpStatus(ctx, helpID, status)
+	if errors.Is(err, storage.ErrNotFound) {
+		return ErrNotFound
+	}
+	return err
+}
+
+// BannedPhrases returns phrases that make new helps suspicious.
+func (s *Service) BannedPhrases(ctx context.Context) ([]string, error) {
+	ps, err := s.storage.SelectBannedPhrases(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	phrases := make([]string, 0, len(ps))
+	for _, p := range ps {
+		phrases = append(phrases, p.Phrase)
+	}
+	return phrases, nil
+}
+
+func (s *Service) BanPhrase(ctx context.Context, phrase string) error {
+	phrase = strings.ToLower(strings.TrimSpace(phrase))
+	if phrase == "" {
+		return ErrInvalidInput
+	}
+
+	err := s.storage.InsertBannedPhrase(ctx, phrase)
+	if errors.Is(err, storage.ErrUniqueViolation) {
+		return ErrAlreadyExists
+	}
+	return err
+}
+
+func (s *Service) UnbanPhrase(ctx context.Context, phrase string) error {
+	err := s.storage.DeleteBannedPhrase(ctx, strings.ToLower(strings.TrimSpace(phrase)))
+	if errors.Is(err, storage.ErrNotFound) {
+		return ErrNotFound
+	}
+	return err
+}
@@ -0,0 +1,56 @@
+package service
+
+import (
+	"context"
+	"testing"
+)
+
+func TestLuhn(t *testing.T) {
+	tests := []struct {
+		digits string
+		want   bool
+	}{
+		{"4111111111111111", true},
+		{"5555555555554444", true},
+		{"378282246310005", true},
+		{"4000056655665556", true},
+		{"4111111111111112", false},
+		{"5555555555554440", false},
+		{"380671234561", true}, // phones may pass the checksum, length rules them out
+		{"380671234567", false},
+		{"0671234565", true},
+		{"0671234567", false},
+	}
+
+	for _, tt := range tests {
+		if got := luhn(tt.digits); got != tt.want {
+			t.Errorf("luhn(%s) = %v, want %v", tt.digits, got, tt.want)
+		}
+	}
+}
+
+func TestCardNumberRule(t *testing.T) {
+	tests := []struct {
+		description string
+		want        int
+	}{
+		{"Donate to 4111 1111 1111 1111", 100},
+		{"Card 5555-5555-5555-4444, thanks", 100},
+		{"Amex 378282246310005", 100},
+		{"Card 4111 1111 1111 1112", 0},
+		{"Call +380671234561", 0},
+		{"Call 067 123 45 65", 0},
+		{"Call 380671234561, 380671234567", 0},
+		{"Free hot meals every day", 0},
+	}
+
+	for _, tt := range tests {
+		got, _, err := cardNumberRule(context.Background(), NewHelp{Description: tt.description})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != tt.want {
+			t.Errorf("cardNumberRule(%q) = %d, want %d", tt.description, got, tt.want)
+		}
+	}
+}
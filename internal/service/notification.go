@@ -0,0 +1,306 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"time"
+	_ "time/tzdata" // quiet hours are computed in Kyiv time regardless of the host timezone
+
+	"github.com/google/uuid"
+	"github.com/rvkinc/uasocial/internal/storage"
+)
+
+// Notification modes
+const (
+	NotificationInstant = "INSTANT"
+	NotificationHourly  = "HOURLY"
+	NotificationDaily   = "DAILY"
+)
+
+const (
+	notificationQueueInterval = time.Minute
+	dailyDigestHour           = 9 // Kyiv time
+)
+
+var kyivLocation = loadKyivLocation()
+
+func loadKyivLocation() *time.Location {
+	l, err := time.LoadLocation("Europe/Kiev")
+	if err != nil {
+		return time.FixedZone("EET", 2*60*60)
+	}
+	return l
+}
+
+type (
+	NotificationPreferences struct {
+		Mode string
+		// QuietHoursFrom and QuietHoursTo are hours in Kyiv time, nil when quiet hours are off
+		QuietHoursFrom *int
+		QuietHoursTo   *int
+	}
+
+	// Digest is a set of queued helps grouped by subscription,
+	// it's marked sent with DigestSent once the bot delivers it.
+	Digest struct {
+		ChatID        int64
+		Language      string
+		Subscriptions []DigestSubscription
+
+		userID    uuid.UUID
+		queuedIDs []uuid.UUID
+		dueAt     time.Time
+	}
+
+	DigestSubscription struct {
+		UserSubscription
+		Helps []UserHelp
+	}
+)
+
+// pendingDigests are users whose digests are passed to the bot but not acknowledged yet,
+// their queued notifications are skipped so the digest isn't built twice.
+type pendingDigests struct {
+	mu    *sync.Mutex
+	users map[uuid.UUID]bool
+}
+
+func (p *pendingDigests) add(uid uuid.UUID) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.users[uid] = true
+}
+
+func (p *pendingDigests) has(uid uuid.UUID) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.users[uid]
+}
+
+func (p *pendingDigests) delete(uid uuid.UUID) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.users, uid)
+}
+
+// Digests receives queued notifications when they are due.
+func (s *Service) Digests() chan Digest { return s.digestsCh }
+
+// DigestSent acknowledges the digest received from Digests. Delivered digests are marked sent
+// and their notifications are removed from the queue, failed ones are built again on the next tick.
+func (s *Service) DigestSent(ctx context.Context, d Digest, sendErr error) error {
+	defer s.pendingDigests.delete(d.userID)
+	if sendErr != nil {
+		return nil
+	}
+	return s.storage.CompleteDigests(ctx, []uuid.UUID{d.userID}, d.queuedIDs, d.dueAt)
+}
+
+// NotificationPreferences returns user notification preferences.
+func (s *Service) NotificationPreferences(ctx context.Context, uid uuid.UUID) (NotificationPreferences, error) {
+	p, err := s.Preferences(ctx, uid)
+	return p.NotificationPreferences, err
+}
+
+// SetNotificationPreferences updates user notification preferences.
+func (s *Service) SetNotificationPreferences(ctx context.Context, uid uuid.UUID, np NotificationPreferences) error {
+	p, err := s.Preferences(ctx, uid)
+	if err != nil {
+		return err
+	}
+
+	p.NotificationPreferences = np
+	return s.SetPreferences(ctx, uid, p)
+}
+
+func (np NotificationPreferences) validate() error {
+	switch np.Mode {
+	case NotificationInstant, NotificationHourly, NotificationDaily:
+	default:
+		return ErrInvalidInput
+	}
+
+	if (np.QuietHoursFrom == nil) != (np.QuietHoursTo == nil) ||
+		(np.QuietHoursFrom != nil && (!validHour(*np.QuietHoursFrom) || !validHour(*np.QuietHoursTo))) {
+		return ErrInvalidInput
+	}
+
+	return nil
+}
+
+func validHour(h int) bool { return h >= 0 && h < 24 }
+
+// isQuiet reports whether t is within quiet hours.
+func (np NotificationPreferences) isQuiet(t time.Time) bool {
+	if np.QuietHoursFrom == nil || np.QuietHoursTo == nil {
+		return false
+	}
+
+	var (
+		h        = t.In(kyivLocation).Hour()
+		from, to = *np.QuietHoursFrom, *np.QuietHoursTo
+	)
+
+	switch {
+	case from < to:
+		return h >= from && h < to
+	case from > to: // overnight
+		return h >= from || h < to
+	default:
+		return false
+	}
+}
+
+// isDigestDue reports whether queued notifications should be sent at t.
+// Instant notifications are queued only during quiet hours, so they are sent right after.
+func (np NotificationPreferences) isDigestDue(t time.Time, lastDigestAt *time.Time) bool {
+	if np.isQuiet(t) {
+		return false
+	}
+
+	if lastDigestAt == nil {
+		return np.Mode != NotificationDaily || t.In(kyivLocation).Hour() >= dailyDigestHour
+	}
+
+	var (
+		kt   = t.In(kyivLocation)
+		last = lastDigestAt.In(kyivLocation)
+	)
+
+	switch np.Mode {
+	case NotificationHourly:
+		return last.Before(kt.Truncate(time.Hour))
+	case NotificationDaily:
+		digestTime := time.Date(kt.Year(), kt.Month(), kt.Day(), dailyDigestHour, 0, 0, 0, kyivLocation)
+		return !kt.Before(digestTime) && last.Before(digestTime)
+	default:
+		return true
+	}
+}
+
+// notifyOrQueue sends help to subscription owner or group immediately or queues it for the digest.
+func (s *Service) notifyOrQueue(ctx context.Context, sub *storage.SubscriptionValue, help UserHelp, now time.Time) (*SubscriptionMessage, error) {
+	// groups are not subject to personal preferences of the subscription creator
+	if sub.GroupChatID != nil {
+		return &SubscriptionMessage{UserHelp: help, ChatID: *sub.GroupChatID, Language: sub.Language}, nil
+	}
+
+	np := NotificationPreferences{
+		Mode:           sub.NotificationMode,
+		QuietHoursFrom: sub.QuietHoursFrom,
+		QuietHoursTo:   sub.QuietHoursTo,
+	}
+
+	if np.Mode == NotificationInstant && !np.isQuiet(now) {
+		return &SubscriptionMessage{UserHelp: help, ChatID: sub.ChatID, Language: sub.Language}, nil
+	}
+
+	return nil, s.storage.InsertQueuedNotification(ctx, &storage.QueuedNotificationInsert{
+		UserID:         sub.CreatorID,
+		SubscriptionID: sub.ID,
+		HelpID:         help.ID,
+	})
+}
+
+func (s *Service) handleNotificationQueue() {
+	ticker := time.NewTicker(notificationQueueInterval).C
+	for range ticker {
+		digests, err := s.dueDigests(context.Background(), time.Now())
+		if err != nil {
+			// log here
+			continue
+		}
+
+		for _, d := range digests {
+			s.digestsCh <- d
+		}
+	}
+}
+
+// dueDigests collects digests of users whose queued notifications are due and marks the users pending.
+// Digests left without helps, e.g. all of them were deleted, are completed right away.
+func (s *Service) dueDigests(ctx context.Context, now time.Time) ([]Digest, error) {
+	queued, err := s.storage.SelectQueuedNotifications(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		digests    = make([]Digest, 0)
+		emptyUsers = make([]uuid.UUID, 0)
+		emptyIDs   = make([]uuid.UUID, 0)
+		digest     *Digest
+	)
+
+	flush := func() {
+		switch {
+		case digest == nil:
+		case len(digest.Subscriptions) == 0:
+			emptyUsers = append(emptyUsers, digest.userID)
+			emptyIDs = append(emptyIDs, digest.queuedIDs...)
+		default:
+			digests = append(digests, *digest)
+		}
+	}
+
+	// queued notifications are ordered by user and subscription
+	for _, q := range queued {
+		np := NotificationPreferences{
+			Mode:           q.NotificationMode,
+			QuietHoursFrom: q.QuietHoursFrom,
+			QuietHoursTo:   q.QuietHoursTo,
+		}
+
+		if !np.isDigestDue(now, q.LastDigestAt) || s.pendingDigests.has(q.UserID) {
+			continue
+		}
+
+		if digest == nil || digest.userID != q.UserID {
+			flush()
+			digest = &Digest{ChatID: q.ChatID, Language: q.Language, userID: q.UserID, dueAt: now}
+		}
+
+		digest.queuedIDs = append(digest.queuedIDs, q.ID)
+		if q.Help.DeletedAt != nil {
+			continue
+		}
+
+		if n := len(digest.Subscriptions); n == 0 || digest.Subscriptions[n-1].ID != q.SubscriptionID {
+			sub := q.Subscription
+			us := UserSubscription{
+				ID:        sub.ID,
+				CreatorID: sub.CreatorID,
+				CreatedAt: sub.CreatedAt,
+			}
+			if sub.Keywords != nil {
+				us.Keywords = *sub.Keywords
+			}
+			us.localize(&sub)
+			digest.Subscriptions = append(digest.Subscriptions, DigestSubscription{UserSubscription: us})
+		}
+
+		h := UserHelp{
+			ID:          q.Help.ID,
+			CreatorID:   q.Help.CreatorID,
+			Description: q.Help.Description,
+			CreatedAt:   q.Help.CreatedAt,
+		}
+		h.localize(&q.Help)
+
+		ds := &digest.Subscriptions[len(digest.Subscriptions)-1]
+		ds.Helps = append(ds.Helps, h)
+	}
+	flush()
+
+	if len(emptyUsers) > 0 {
+		err = s.storage.CompleteDigests(ctx, emptyUsers, emptyIDs, now)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for _, d := range digests {
+		s.pendingDigests.add(d.userID)
+	}
+	return digests, nil
+}
@@ -0,0 +1,157 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rvkinc/uasocial/internal/storage"
+)
+
+func hour(h int) *int { return &h }
+
+func kyivTime(day, h, m int) time.Time {
+	return time.Date(2022, time.April, day, h, m, 0, 0, kyivLocation)
+}
+
+func TestIsQuiet(t *testing.T) {
+	tests := []struct {
+		name     string
+		from, to *int
+		at       time.Time
+		want     bool
+	}{
+		{"off", nil, nil, kyivTime(10, 3, 0), false},
+		{"within day", hour(13), hour(15), kyivTime(10, 14, 30), true},
+		{"day end is excluded", hour(13), hour(15), kyivTime(10, 15, 0), false},
+		{"before day", hour(13), hour(15), kyivTime(10, 12, 59), false},
+		{"overnight evening", hour(22), hour(8), kyivTime(10, 23, 0), true},
+		{"overnight morning", hour(22), hour(8), kyivTime(10, 7, 59), true},
+		{"overnight day", hour(22), hour(8), kyivTime(10, 8, 0), false},
+		{"equal hours", hour(5), hour(5), kyivTime(10, 5, 0), false},
+		{"utc is converted", hour(22), hour(8), time.Date(2022, time.April, 10, 20, 0, 0, 0, time.UTC), true}, // 23:00 in Kyiv
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			np := NotificationPreferences{QuietHoursFrom: tt.from, QuietHoursTo: tt.to}
+			if got := np.isQuiet(tt.at); got != tt.want {
+				t.Errorf("isQuiet() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsDigestDue(t *testing.T) {
+	at := func(day, h, m int) *time.Time {
+		t := kyivTime(day, h, m)
+		return &t
+	}
+
+	tests := []struct {
+		name string
+		np   NotificationPreferences
+		now  time.Time
+		last *time.Time
+		want bool
+	}{
+		{"instant after quiet hours", NotificationPreferences{Mode: NotificationInstant, QuietHoursFrom: hour(22), QuietHoursTo: hour(8)}, kyivTime(10, 8, 1), at(9, 21, 0), true},
+		{"instant during quiet hours", NotificationPreferences{Mode: NotificationInstant, QuietHoursFrom: hour(22), QuietHoursTo: hour(8)}, kyivTime(10, 7, 0), at(9, 21, 0), false},
+		{"hourly first digest", NotificationPreferences{Mode: NotificationHourly}, kyivTime(10, 10, 5), nil, true},
+		{"hourly sent this hour", NotificationPreferences{Mode: NotificationHourly}, kyivTime(10, 10, 59), at(10, 10, 1), false},
+		{"hourly sent last hour", NotificationPreferences{Mode: NotificationHourly}, kyivTime(10, 11, 0), at(10, 10, 59), true},
+		{"daily before digest hour", NotificationPreferences{Mode: NotificationDaily}, kyivTime(10, 8, 59), at(9, 9, 0), false},
+		{"daily at digest hour", NotificationPreferences{Mode: NotificationDaily}, kyivTime(10, 9, 0), at(9, 9, 0), true},
+		{"daily sent today", NotificationPreferences{Mode: NotificationDaily}, kyivTime(10, 18, 0), at(10, 9, 1), false},
+		{"daily first digest before hour", NotificationPreferences{Mode: NotificationDaily}, kyivTime(10, 6, 0), nil, false},
+		{"daily during quiet hours", NotificationPreferences{Mode: NotificationDaily, QuietHoursFrom: hour(8), QuietHoursTo: hour(10)}, kyivTime(10, 9, 30), at(9, 10, 0), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.np.isDigestDue(tt.now, tt.last); got != tt.want {
+				t.Errorf("isDigestDue() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// digestStorage serves queued notifications and records completed digests.
+type digestStorage struct {
+	storage.Interface
+	queued    []*storage.QueuedNotification
+	completed []uuid.UUID // queued notifications removed from the queue
+}
+
+func (s *digestStorage) SelectQueuedNotifications(context.Context) ([]*storage.QueuedNotification, error) {
+	return s.queued, nil
+}
+
+func (s *digestStorage) CompleteDigests(_ context.Context, _, queuedIDs []uuid.UUID, _ time.Time) error {
+	s.completed = append(s.completed, queuedIDs...)
+	return nil
+}
+
+func TestDueDigests(t *testing.T) {
+	var (
+		now        = kyivTime(10, 12, 0)
+		deletedAt  = now.Add(-time.Minute)
+		alice, bob = uuid.New(), uuid.New()
+		sub        = storage.SubscriptionValue{ID: uuid.New()}
+		queued     = func(uid uuid.UUID, deleted *time.Time) *storage.QueuedNotification {
+			return &storage.QueuedNotification{
+				ID:               uuid.New(),
+				UserID:           uid,
+				SubscriptionID:   sub.ID,
+				NotificationMode: NotificationHourly,
+				Help:             storage.Help{ID: uuid.New(), DeletedAt: deleted},
+				Subscription:     sub,
+			}
+		}
+		aliceHelp  = queued(alice, nil)
+		bobDeleted = queued(bob, &deletedAt)
+		st         = &digestStorage{queued: []*storage.QueuedNotification{aliceHelp, bobDeleted}}
+		s          = &Service{storage: st, pendingDigests: &pendingDigests{mu: &sync.Mutex{}, users: make(map[uuid.UUID]bool)}}
+		ctx        = context.Background()
+	)
+
+	digests, err := s.dueDigests(ctx, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(digests) != 1 || digests[0].userID != alice || len(digests[0].Subscriptions[0].Helps) != 1 {
+		t.Fatalf("dueDigests() = %+v, want one digest of alice", digests)
+	}
+	if len(st.completed) != 1 || st.completed[0] != bobDeleted.ID {
+		t.Fatalf("completed %v, want the deleted help of bob only", st.completed)
+	}
+
+	// the digest isn't built again until the bot acknowledges it
+	st.queued = []*storage.QueuedNotification{aliceHelp}
+	if again, _ := s.dueDigests(ctx, now); len(again) != 0 {
+		t.Fatalf("pending digest is built again: %+v", again)
+	}
+
+	// a failed send leaves the notifications queued for the next tick
+	if err = s.DigestSent(ctx, digests[0], errors.New("blocked")); err != nil {
+		t.Fatal(err)
+	}
+	if len(st.completed) != 1 {
+		t.Fatalf("failed digest is completed: %v", st.completed)
+	}
+
+	digests, err = s.dueDigests(ctx, now)
+	if err != nil || len(digests) != 1 {
+		t.Fatalf("dueDigests() = %+v, %v, want the failed digest again", digests, err)
+	}
+
+	if err = s.DigestSent(ctx, digests[0], nil); err != nil {
+		t.Fatal(err)
+	}
+	if len(st.completed) != 2 || st.completed[1] != aliceHelp.ID {
+		t.Fatalf("completed %v, want the help of alice too", st.completed)
+	}
+}
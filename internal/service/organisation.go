@@ -0,0 +1,138 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/rvkinc/uasocial/internal/storage"
+)
+
+type (
+	// Organisation is an NGO, a city council or another group volunteers post helps on behalf of.
+	Organisation struct {
+		ID          uuid.UUID
+		Name        string
+		Description string
+		Contact     string
+		Verified    bool
+		Members     int // set for the full list only
+	}
+
+	// HelpOrganisation is the organisation a help is posted on behalf of.
+	HelpOrganisation struct {
+		Name        string
+		Verified    bool
+		Description string // set for a single help
+		Contact     string // set for a single help
+	}
+)
+
+func helpOrganisation(o storage.HelpOrganisation) *HelpOrganisation {
+	if o.OrganisationName == nil {
+		return nil
+	}
+
+	ho := &HelpOrganisation{Name: *o.OrganisationName, Verified: o.OrganisationVerified}
+	if o.OrganisationDescription != nil {
+		ho.Description = *o.OrganisationDescription
+	}
+	if o.OrganisationContact != nil {
+		ho.Contact = *o.OrganisationContact
+	}
+	return ho
+}
+
+func organisations(os []*storage.Organisation) []Organisation {
+	orgs := make([]Organisation, 0, len(os))
+	for _, o := range os {
+		orgs = append(orgs, Organisation{
+			ID:          o.ID,
+			Name:        o.Name,
+			Description: o.Description,
+			Contact:     o.Contact,
+			Verified:    o.Verified,
+			Members:     o.Members,
+		})
+	}
+	return orgs
+}
+
+// NewOrganisation creates unverified organisation, names are unique.
+func (s *Service) NewOrganisation(ctx context.Context, name, description, contact string) (uuid.UUID, error) {
+	name, description, contact = strings.TrimSpace(name), strings.TrimSpace(description), strings.TrimSpace(contact)
+	if name == "" {
+		return uuid.Nil, ErrInvalidInput
+	}
+
+	id, err := s.storage.InsertOrganisation(ctx, &storage.OrganisationInsert{
+		Name:        name,
+		Description: description,
+		Contact:     contact,
+	})
+	if errors.Is(err, storage.ErrUniqueViolation) {
+		return uuid.Nil, ErrAlreadyExists
+	}
+	return id, err
+}
+
+// Organisations returns all organisations.
+func (s *Service) Organisations(ctx context.Context) ([]Organisation, error) {
+	os, err := s.storage.SelectOrganisations(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return organisations(os), nil
+}
+
+// UserOrganisations returns organisations the user may post helps on behalf of.
+func (s *Service) UserOrganisations(ctx context.Context, uid uuid.UUID) ([]Organisation, error) {
+	os, err := s.storage.SelectOrganisationsByUser(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+	return organisations(os), nil
+}
+
+func (s *Service) VerifyOrganisation(ctx context.Context, id uuid.UUID, verified bool) error {
+	return organisationErr(s.storage.UpdateOrganisationVerified(ctx, id, verified))
+}
+
+// AddOrganisationMember links user by telegram id, ErrNotFound is returned if the user never started the bot.
+func (s *Service) AddOrganisationMember(ctx context.Context, id uuid.UUID, tgID int) error {
+	return organisationErr(s.storage.InsertOrganisationMember(ctx, id, tgID))
+}
+
+func (s *Service) RemoveOrganisationMember(ctx context.Context, id uuid.UUID, tgID int) error {
+	return organisationErr(s.storage.DeleteOrganisationMember(ctx, id, tgID))
+}
+
+// validateOrganisation checks the user may post on behalf of the organisation.
+func (s *Service) validateOrganisation(ctx context.Context, uid uuid.UUID, orgID *uuid.UUID) error {
+	if orgID == nil {
+		return nil
+	}
+
+	orgs, err := s.UserOrganisations(ctx, uid)
+	if err != nil {
+		return err
+	}
+
+	for _, o := range orgs {
+		if o.ID == *orgID {
+			return nil
+		}
+	}
+	return ErrInvalidInput
+}
+
+func organisationErr(err error) error {
+	switch {
+	case errors.Is(err, storage.ErrNotFound):
+		return ErrNotFound
+	case errors.Is(err, storage.ErrUniqueViolation):
+		return ErrAlreadyExists
+	}
+	return err
+}
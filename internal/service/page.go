@@ -0,0 +1,90 @@
+package service
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/rvkinc/uasocial/internal/storage"
+)
+
+type (
+	// Cursor points at the last item of a page, helps, users and the like are listed by ascending sort key and id.
+	Cursor struct {
+		SortKey float64
+		ID      uuid.UUID
+	}
+
+	// HelpPage is a page of helps, Next is nil on the last page.
+	HelpPage struct {
+		Helps []UserHelp
+		Next  *Cursor
+	}
+)
+
+// String encodes cursor to be passed around as an opaque value, e.g. inline query offset.
+func (c Cursor) String() string {
+	return fmt.Sprintf("%s_%s", strconv.FormatFloat(c.SortKey, 'g', -1, 64), c.ID)
+}
+
+// ParseCursor decodes cursor encoded by Cursor.String.
+func ParseCursor(s string) (*Cursor, error) {
+	parts := strings.SplitN(s, "_", 2)
+	if len(parts) != 2 {
+		return nil, ErrInvalidInput
+	}
+
+	key, err := strconv.ParseFloat(parts[0], 64)
+	if err != nil {
+		return nil, ErrInvalidInput
+	}
+
+	id, err := uuid.Parse(parts[1])
+	if err != nil {
+		return nil, ErrInvalidInput
+	}
+
+	return &Cursor{SortKey: key, ID: id}, nil
+}
+
+// storagePage requests one extra help to find out whether there is a next page.
+func storagePage(after *Cursor, limit int) storage.Page {
+	page := storage.Page{Limit: limit + 1}
+	if after != nil {
+		page.AfterKey = &after.SortKey
+		page.AfterID = after.ID
+	}
+	return page
+}
+
+// helpPage localizes helps selected with storagePage.
+func helpPage(hs []*storage.Help, limit int) HelpPage {
+	var page HelpPage
+	if len(hs) > limit {
+		hs = hs[:limit]
+		last := hs[len(hs)-1]
+		page.Next = &Cursor{SortKey: last.SortKey, ID: last.ID}
+	}
+
+	page.Helps = make([]UserHelp, 0, len(hs))
+	for _, help := range hs {
+		h := UserHelp{
+			ID:               help.ID,
+			CreatorID:        help.CreatorID,
+			Description:      help.Description,
+			CreatedAt:        help.CreatedAt,
+			AttachmentsCount: help.AttachmentsCount,
+			Details:          helpDetails(help.HelpDetails),
+			Pending:          help.Status == HelpStatusPending,
+			Organisation:     helpOrganisation(help.HelpOrganisation),
+			Reputation:       helpReputation(help.HelpReputation),
+		}
+		if help.Headline != nil {
+			h.Headline = *help.Headline
+		}
+		h.localize(help)
+		page.Helps = append(page.Helps, h)
+	}
+	return page
+}
@@ -0,0 +1,56 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/rvkinc/uasocial/internal/storage"
+)
+
+func TestCursor(t *testing.T) {
+	id := uuid.MustParse("7b1c3c39-5a1d-4d3b-9a55-2f6bfb6f0e51")
+
+	for _, c := range []Cursor{
+		{SortKey: 0, ID: id},
+		{SortKey: 1650000000, ID: id},
+		{SortKey: -0.125, ID: id},
+		{SortKey: 1e-9, ID: id},
+	} {
+		got, err := ParseCursor(c.String())
+		if err != nil || *got != c {
+			t.Errorf("ParseCursor(%q) = %+v, %v, want %+v", c.String(), got, err, c)
+		}
+	}
+
+	for _, s := range []string{
+		"",
+		"12",
+		"12_",
+		"_" + id.String(),
+		"twelve_" + id.String(),
+		"12_" + id.String()[1:],
+	} {
+		if _, err := ParseCursor(s); !errors.Is(err, ErrInvalidInput) {
+			t.Errorf("ParseCursor(%q) = %v, want ErrInvalidInput", s, err)
+		}
+	}
+}
+
+func TestHelpPage(t *testing.T) {
+	hs := []*storage.Help{
+		{ID: uuid.New(), SortKey: 3},
+		{ID: uuid.New(), SortKey: 2},
+		{ID: uuid.New(), SortKey: 1},
+	}
+
+	page := helpPage(hs, 2)
+	if len(page.Helps) != 2 || page.Next == nil || *page.Next != (Cursor{SortKey: 2, ID: hs[1].ID}) {
+		t.Errorf("helpPage() = %d helps, next %+v, want 2 helps and a cursor at the second", len(page.Helps), page.Next)
+	}
+
+	page = helpPage(hs, 3)
+	if len(page.Helps) != 3 || page.Next != nil {
+		t.Errorf("helpPage() = %d helps, next %+v, want the last page", len(page.Helps), page.Next)
+	}
+}
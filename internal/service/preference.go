@@ -0,0 +1,100 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/rvkinc/uasocial/internal/storage"
+)
+
+// Languages
+const (
+	LanguageUA = "UA"
+	LanguageRU = "RU"
+	LanguageEN = "EN"
+)
+
+const maxSearchRadius = 200 // km
+
+type Preferences struct {
+	NotificationPreferences
+
+	// SearchRadius is km around the chosen locality to search helps in,
+	// 0 searches the locality and its neighbourhood only
+	SearchRadius    int
+	DefaultLocality *Locality
+	ShareContact    bool
+}
+
+// Preferences returns user preferences, defaults are returned if user has never changed them.
+func (s *Service) Preferences(ctx context.Context, uid uuid.UUID) (Preferences, error) {
+	pref, err := s.storage.SelectUserPreference(ctx, uid)
+	if err != nil {
+		return Preferences{}, err
+	}
+
+	p := Preferences{
+		NotificationPreferences: NotificationPreferences{
+			Mode:           pref.NotificationMode,
+			QuietHoursFrom: pref.QuietHoursFrom,
+			QuietHoursTo:   pref.QuietHoursTo,
+		},
+		SearchRadius: pref.SearchRadius,
+		ShareContact: pref.ShareContact,
+	}
+
+	if pref.DefaultLocalityID != nil {
+		p.DefaultLocality = &Locality{ID: *pref.DefaultLocalityID}
+		if pref.DefaultLocalityName != nil {
+			p.DefaultLocality.Name = *pref.DefaultLocalityName
+		}
+		if pref.DefaultLocalityRegionName != nil {
+			p.DefaultLocality.RegionName = *pref.DefaultLocalityRegionName
+		}
+	}
+
+	return p, nil
+}
+
+// SetPreferences updates user preferences.
+func (s *Service) SetPreferences(ctx context.Context, uid uuid.UUID, p Preferences) error {
+	err := p.NotificationPreferences.validate()
+	if err != nil {
+		return err
+	}
+
+	if p.SearchRadius < 0 || p.SearchRadius > maxSearchRadius {
+		return ErrInvalidInput
+	}
+
+	pref := &storage.UserPreference{
+		UserID:           uid,
+		NotificationMode: p.Mode,
+		QuietHoursFrom:   p.QuietHoursFrom,
+		QuietHoursTo:     p.QuietHoursTo,
+		SearchRadius:     p.SearchRadius,
+		ShareContact:     p.ShareContact,
+	}
+
+	if p.DefaultLocality != nil {
+		pref.DefaultLocalityID = &p.DefaultLocality.ID
+	}
+
+	return s.storage.UpsertUserPreference(ctx, pref)
+}
+
+// SetLanguage changes user interface language.
+func (s *Service) SetLanguage(ctx context.Context, uid uuid.UUID, lang string) error {
+	switch lang {
+	case LanguageUA, LanguageRU, LanguageEN:
+	default:
+		return ErrInvalidInput
+	}
+
+	err := s.storage.UpdateUserLanguage(ctx, uid, lang)
+	if errors.Is(err, storage.ErrNotFound) {
+		return ErrNotFound
+	}
+	return err
+}
@@ -0,0 +1,201 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/rvkinc/uasocial/internal/storage"
+)
+
+const minQueryWordLength = 3
+
+const (
+	SortNewest    HelpSort = "NEWEST"
+	SortNearest   HelpSort = "NEAREST"
+	SortConfirmed HelpSort = "CONFIRMED" // recently kept by the volunteer
+)
+
+type (
+	HelpSort string
+
+	// HelpQuery describes help search in categories around the locality.
+	HelpQuery struct {
+		LocalityID   int
+		CategoryIDs  []uuid.UUID
+		Radius       int // km, helps in localities within the radius are included when positive
+		Sort         HelpSort
+		PostedWithin time.Duration // zero for helps posted at any time
+		HasPhone     bool          // help has a contact phone or a phone number in the description
+		AvailableNow bool          // availability window of the help includes the current time
+		Language     string        // volunteer speaks the language
+		HasCapacity  bool
+		VerifiedOnly bool // posted on behalf of verified organisations
+	}
+)
+
+func (s HelpSort) Valid() bool {
+	return s == SortNewest || s == SortNearest || s == SortConfirmed
+}
+
+// HelpsByQuery searches helps by a free text query made of category and locality names,
+// e.g. "food Київ". Words that do not match any category are treated as the locality name.
+// All categories are searched when the query mentions none.
+func (s *Service) HelpsByQuery(ctx context.Context, query string, after *Cursor, limit int) (HelpPage, error) {
+	categories, err := s.GetCategories(ctx)
+	if err != nil {
+		return HelpPage{}, err
+	}
+
+	var (
+		visible  = categories.Visible()
+		cids     = make([]uuid.UUID, 0)
+		locality = make([]string, 0)
+	)
+
+	for _, w := range strings.Fields(query) {
+		matched := visible.MatchWord(w)
+		if len(matched) == 0 {
+			locality = append(locality, w)
+			continue
+		}
+		cids = append(cids, matched...)
+	}
+
+	if len(locality) == 0 {
+		return HelpPage{}, nil
+	}
+
+	localities, err := s.AutocompleteLocality(ctx, strings.Join(locality, " "))
+	if err != nil {
+		return HelpPage{}, err
+	}
+
+	if len(localities) == 0 {
+		return HelpPage{}, nil
+	}
+
+	if len(cids) == 0 {
+		for _, c := range visible {
+			cids = append(cids, c.ID)
+		}
+	}
+
+	return s.HelpsByCategoryLocation(ctx, HelpQuery{LocalityID: localities[0].ID, CategoryIDs: cids}, after, limit)
+}
+
+// MatchWord returns categories having a word in any language that starts with w.
+func (cs *Categories) MatchWord(w string) []uuid.UUID {
+	w = strings.ToLower(w)
+	if utf8.RuneCountInString(w) < minQueryWordLength {
+		return nil
+	}
+
+	ids := make([]uuid.UUID, 0)
+	for _, c := range *cs {
+		for _, name := range []string{c.NameUA, c.NameRU, c.NameEN} {
+			if hasWordPrefix(strings.ToLower(name), w) {
+				ids = append(ids, c.ID)
+				break
+			}
+		}
+	}
+	return ids
+}
+
+func hasWordPrefix(s, prefix string) bool {
+	for _, w := range strings.FieldsFunc(s, func(r rune) bool { return r == ' ' || r == ',' || r == '/' || r == '(' || r == ')' }) {
+		if strings.HasPrefix(w, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+const (
+	// HighlightStart and HighlightEnd surround matched words in help headlines.
+	HighlightStart = "\ue000" // private use characters, not expected in descriptions
+	HighlightEnd   = "\ue001"
+
+	minStemLength = 4
+)
+
+var (
+	headlineOptions = fmt.Sprintf("StartSel=%s, StopSel=%s, MinWords=10, MaxWords=30, MaxFragments=2", HighlightStart, HighlightEnd)
+
+	// ukrainianEndings are cut from words to match their other forms by prefix, longer endings go first
+	ukrainianEndings = []string{
+		"ами", "ями", "ові", "еві", "ого", "ому", "ими", "ій", "ий", "ої", "ою", "ею", "ів", "их", "іх",
+		"ах", "ях", "ом", "ем", "а", "я", "у", "ю", "і", "и", "е", "о", "ь",
+	}
+)
+
+// TextSearch searches helps by description, optionally within a region.
+type TextSearch struct {
+	Text        string
+	RegionID    int         // oblast or any other locality, zero for the whole country
+	CategoryIDs []uuid.UUID // any category when empty
+}
+
+// SearchHelps returns a page of helps with descriptions matching the text, the most relevant first.
+func (s *Service) SearchHelps(ctx context.Context, q TextSearch, after *Cursor, limit int) (HelpPage, error) {
+	prefixQuery := searchTerms(q.Text)
+	if prefixQuery == "" {
+		return HelpPage{}, nil
+	}
+
+	f := storage.TextFilter{
+		PrefixQuery:     prefixQuery,
+		PlainQuery:      q.Text,
+		HeadlineOptions: headlineOptions,
+	}
+
+	if len(q.CategoryIDs) > 0 {
+		categories, err := s.GetCategories(ctx)
+		if err != nil {
+			return HelpPage{}, err
+		}
+		f.CategoryIDs = categories.WithDescendants(q.CategoryIDs)
+	}
+
+	if q.RegionID != 0 {
+		f.RegionID = &q.RegionID
+	}
+
+	hs, err := s.storage.SelectHelpsByText(ctx, f, storagePage(after, limit))
+	if err != nil {
+		return HelpPage{}, err
+	}
+	return helpPage(hs, limit), nil
+}
+
+// searchTerms returns tsquery matching all words of the text by prefix with common ukrainian endings cut,
+// e.g. "інсуліну Humalog" becomes "інсулін:* & humalog:*".
+func searchTerms(text string) string {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsDigit(r) })
+
+	terms := make([]string, 0, len(words))
+	for _, w := range words {
+		terms = append(terms, stem(w)+":*")
+	}
+	return strings.Join(terms, " & ")
+}
+
+// stem cuts ukrainian ending from cyrillic word keeping at least minStemLength letters.
+func stem(w string) string {
+	r := []rune(w)
+	if !unicode.Is(unicode.Cyrillic, r[0]) {
+		return w
+	}
+
+	for _, e := range ukrainianEndings {
+		if strings.HasSuffix(w, e) && len(r)-utf8.RuneCountInString(e) >= minStemLength {
+			return strings.TrimSuffix(w, e)
+		}
+	}
+	return w
+}
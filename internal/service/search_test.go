@@ -0,0 +1,48 @@
+package service
+
+import "testing"
+
+func TestStem(t *testing.T) {
+	tests := []struct {
+		word string
+		want string
+	}{
+		{"інсуліну", "інсулін"},
+		{"продуктами", "продукт"},
+		{"машиною", "машин"},
+		{"одягу", "одяг"},
+		{"хліба", "хліб"},
+		{"ліки", "ліки"},   // the stem would be too short
+		{"ліків", "ліків"}, // neither ending leaves enough letters
+		{"їжа", "їжа"},
+		{"дітей", "дітей"},
+		{"humalog", "humalog"},
+		{"5", "5"},
+	}
+
+	for _, tt := range tests {
+		if got := stem(tt.word); got != tt.want {
+			t.Errorf("stem(%q) = %q, want %q", tt.word, got, tt.want)
+		}
+	}
+}
+
+func TestSearchTerms(t *testing.T) {
+	tests := []struct {
+		text string
+		want string
+	}{
+		{"інсуліну Humalog", "інсулін:* & humalog:*"},
+		{"Памперси, розмір 5!", "памперс:* & розмір:* & 5:*"},
+		{"corn-flakes & milk | bread", "corn:* & flakes:* & milk:* & bread:*"},
+		{"Їжа", "їжа:*"},
+		{" , ! ", ""},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		if got := searchTerms(tt.text); got != tt.want {
+			t.Errorf("searchTerms(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
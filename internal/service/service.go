@@ -1,22 +1,51 @@
 package service
 
 import (
+	"bytes"
 	"context"
 	"errors"
+	"sort"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/rvkinc/uasocial/internal/storage"
 )
 
+// Locality types
+const (
+	LocalityTypeCountry  = "COUNTRY"
+	LocalityTypeState    = "STATE"
+	LocalityTypeDistrict = "DISTRICT"
+)
+
 var (
 	tenDaysDuration = time.Hour * 24 * 10
-	tenDaysDate     = time.Now().AddDate(0, 0, -10)
 
 	ErrAlreadyExists = errors.New("already exists")
+	ErrNotFound      = errors.New("not found")
+	ErrInvalidInput  = errors.New("invalid input")
 )
 
 type (
+	Config struct {
+		// LinkSecret signs deep link payloads
+		LinkSecret  string `yaml:"link_secret"`
+		BotUsername string `yaml:"bot_username"`
+
+		// OwnerTgID is always an admin, other admins are promoted with /set_tier
+		OwnerTgID int `yaml:"owner_tg_id"`
+
+		// ModeratorsChatID receives held helps, every admin does if it's not set
+		ModeratorsChatID int64 `yaml:"moderators_chat_id"`
+
+		// Channels helps are published to
+		Channels []ChannelConfig `yaml:"channels"`
+
+		Limits LimitsConfig `yaml:"limits"`
+	}
+
 	CreateUser struct {
 		TgID   int
 		ChatID int64
@@ -24,16 +53,22 @@ type (
 	}
 
 	User struct {
-		ID     uuid.UUID
-		TgID   int
-		ChatID int64
-		Name   string
+		ID          uuid.UUID
+		TgID        int
+		ChatID      int64
+		Name        string
+		Language    string
+		Tier        string
+		CreatedAt   time.Time
+		Preferences Preferences
 	}
 
 	CreateSubscription struct {
-		CreatorID  uuid.UUID
-		CategoryID uuid.UUID
-		LocalityID int
+		CreatorID   uuid.UUID
+		CategoryIDs []uuid.UUID
+		LocalityID  int
+		ChatID      *int64 // group chat helps are posted to, nil for personal subscriptions
+		Keywords    string // matched against help descriptions, categories are optional when set
 	}
 
 	UserHelp struct {
@@ -42,15 +77,25 @@ type (
 		Categories  []string
 		Locality    string
 		Description string
+		Headline    string // description fragment with matched words highlighted, set by text search
 		CreatedAt   time.Time
+
+		Attachments      []Attachment // set for a single help, lists have the count only
+		AttachmentsCount int
+		Details          HelpDetails
+		HasContact       bool // contact is given on request, see HelpContact
+		Pending          bool // held for moderation, set for user's helps
+		Organisation     *HelpOrganisation
+		Reputation       Reputation // feedback on all helps of the creator
 	}
 
 	UserSubscription struct {
-		ID        uuid.UUID
-		CreatorID uuid.UUID
-		Category  string
-		Locality  string
-		CreatedAt time.Time
+		ID         uuid.UUID
+		CreatorID  uuid.UUID
+		Categories []string
+		Locality   string
+		Keywords   string
+		CreatedAt  time.Time
 	}
 
 	NewHelp struct {
@@ -58,10 +103,17 @@ type (
 		CategoryIDs []uuid.UUID
 		LocalityID  int
 		Description string
+		Attachments []Attachment
+		Details     HelpDetails
+		Contact     Contact
+
+		// OrganisationID is set if the help is posted on behalf of creator's organisation
+		OrganisationID *uuid.UUID
 	}
 
 	SubscriptionMessage struct {
-		ChatID int64
+		ChatID   int64
+		Language string
 		UserHelp
 	}
 
@@ -75,15 +127,19 @@ type (
 	Localities []Locality
 
 	Category struct {
-		ID     uuid.UUID
-		NameUA string
-		NameRU string
-		NameEN string
+		ID       uuid.UUID
+		NameUA   string
+		NameRU   string
+		NameEN   string
+		ParentID *uuid.UUID
+		Position int
+		Hidden   bool
 	}
 
 	CategoryTranslated struct {
-		ID   uuid.UUID
-		Name string
+		ID       uuid.UUID
+		ParentID *uuid.UUID
+		Name     string
 	}
 
 	Categories []Category
@@ -98,22 +154,48 @@ type (
 
 // Service is a service implementation.
 type Service struct {
+	config                 *Config
 	storage                storage.Interface
 	expiredHelpsCh         chan []UserHelp
 	subscriptionsMessageCh chan []SubscriptionMessage
+	categoriesUpdatedCh    chan struct{}
+	digestsCh              chan Digest
+	pendingDigests         *pendingDigests
+	helpEventsCh           chan HelpEvent
+	moderationEventsCh     chan ModerationEvent
+	feedbackRequestsCh     chan FeedbackRequest
+	contentRules           []ContentRule
 }
 
 func (s *Service) Subscriptions() chan []SubscriptionMessage { return s.subscriptionsMessageCh }
 
+// CategoriesUpdated receives a value every time categories are changed.
+func (s *Service) CategoriesUpdated() chan struct{} { return s.categoriesUpdatedCh }
+
 // NewService returns new service implementation.
-func NewService(storage storage.Interface) *Service {
+func NewService(config *Config, storage storage.Interface) *Service {
+	if config == nil {
+		config = new(Config)
+	}
+
 	s := &Service{
+		config:                 config,
 		storage:                storage,
 		expiredHelpsCh:         make(chan []UserHelp),
 		subscriptionsMessageCh: make(chan []SubscriptionMessage, 100),
+		categoriesUpdatedCh:    make(chan struct{}, 1),
+		digestsCh:              make(chan Digest, 100),
+		pendingDigests:         &pendingDigests{mu: &sync.Mutex{}, users: make(map[uuid.UUID]bool)},
+		helpEventsCh:           make(chan HelpEvent, 100),
+		moderationEventsCh:     make(chan ModerationEvent, 100),
+		feedbackRequestsCh:     make(chan FeedbackRequest, 100),
 	}
+	s.contentRules = s.defaultContentRules()
 
 	go s.handleExpiredHelps()
+	go s.handleExpiredChannelPosts()
+	go s.handleNotificationQueue()
+	go s.handleFeedbackQueue()
 
 	return s
 }
@@ -121,12 +203,24 @@ func NewService(storage storage.Interface) *Service {
 func (s *Service) handleExpiredHelps() {
 	ticker := time.NewTicker(tenDaysDuration).C
 	for range ticker {
-		helps, err := s.expiredHelps(context.Background(), tenDaysDate)
+		helps, err := s.expiredHelps(context.Background(), time.Now().Add(-tenDaysDuration))
 		if err != nil {
 			// log here
 			continue
 		}
-		s.expiredHelpsCh <- helps
+
+		for _, h := range helps {
+			err = s.unpublishHelp(context.Background(), h.ID)
+			if err != nil {
+				// log here
+				continue
+			}
+		}
+
+		select {
+		case s.expiredHelpsCh <- helps:
+		default: // nobody is waiting for expired helps
+		}
 	}
 }
 
@@ -140,11 +234,25 @@ func (s *Service) NewUser(ctx context.Context, user *CreateUser) (User, error) {
 	if err != nil {
 		return User{}, err
 	}
+
+	p, err := s.Preferences(ctx, u.ID)
+	if err != nil {
+		return User{}, err
+	}
+
+	tier := u.Tier
+	if s.isOwner(u.TgID) {
+		tier = TierAdmin
+	}
+
 	return User{
-		ID:     u.ID,
-		TgID:   u.TgID,
-		ChatID: u.ChatID,
-		Name:   u.Name,
+		ID:          u.ID,
+		TgID:        u.TgID,
+		ChatID:      u.ChatID,
+		Name:        u.Name,
+		Language:    u.Language,
+		Tier:        tier,
+		Preferences: p,
 	}, nil
 }
 
@@ -154,6 +262,36 @@ func (s *Service) AutocompleteLocality(ctx context.Context, input string) (Local
 	if err != nil {
 		return nil, err
 	}
+	return localityRegions(ls), nil
+}
+
+// RecentLocalities returns up to n localities recently used by user, the most recent first.
+func (s *Service) RecentLocalities(ctx context.Context, uid uuid.UUID, n int) (Localities, error) {
+	ls, err := s.storage.SelectRecentLocalities(ctx, uid, n)
+	if err != nil {
+		return nil, err
+	}
+	return localityRegions(ls), nil
+}
+
+// RememberLocality marks locality as used by user.
+func (s *Service) RememberLocality(ctx context.Context, uid uuid.UUID, localityID int) error {
+	return s.storage.UpsertUserLocality(ctx, uid, localityID)
+}
+
+// NearestLocality returns settlement nearest to the given coordinates.
+func (s *Service) NearestLocality(ctx context.Context, lat, lng float64) (Locality, error) {
+	l, err := s.storage.SelectNearestLocality(ctx, lat, lng)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return Locality{}, ErrNotFound
+		}
+		return Locality{}, err
+	}
+	return localityRegions([]*storage.LocalityRegion{l})[0], nil
+}
+
+func localityRegions(ls []*storage.LocalityRegion) Localities {
 	localities := make(Localities, 0, len(ls))
 	for _, locality := range ls {
 		localities = append(localities, Locality{
@@ -163,16 +301,88 @@ func (s *Service) AutocompleteLocality(ctx context.Context, input string) (Local
 			RegionName: locality.RegionName,
 		})
 	}
+	return localities
+}
+
+// LocalityAncestors returns locality followed by its district, oblast and country.
+func (s *Service) LocalityAncestors(ctx context.Context, id int) (Localities, error) {
+	ls, err := s.storage.SelectLocalityAncestors(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	localities := make(Localities, 0, len(ls))
+	for _, locality := range ls {
+		localities = append(localities, Locality{
+			ID:   locality.ID,
+			Name: locality.Name,
+			Type: locality.Type,
+		})
+	}
 	return localities, nil
 }
 
+// LocalityByID returns locality with its oblast name.
+func (s *Service) LocalityByID(ctx context.Context, id int) (Locality, error) {
+	ancestors, err := s.LocalityAncestors(ctx, id)
+	if err != nil {
+		return Locality{}, err
+	}
+
+	if len(ancestors) == 0 {
+		return Locality{}, ErrNotFound
+	}
+
+	l := ancestors[0]
+	for _, a := range ancestors[1:] {
+		if a.Type == LocalityTypeState {
+			l.RegionName = a.Name
+		}
+	}
+	return l, nil
+}
+
 // NewSubscription creates new subscription.
+// Subscription locality may be a settlement, district, oblast or the whole country, the latter when it is zero.
 func (s *Service) NewSubscription(ctx context.Context, subscription CreateSubscription) error {
-	err := s.storage.InsertSubscription(ctx, &storage.SubscriptionInsert{
+	keywords := strings.Join(strings.Fields(subscription.Keywords), " ")
+	if len(subscription.CategoryIDs) == 0 && keywords == "" {
+		return ErrInvalidInput
+	}
+
+	insert := &storage.SubscriptionInsert{
 		CreatorID:  subscription.CreatorID,
-		CategoryID: subscription.CategoryID,
 		LocalityID: subscription.LocalityID,
-	})
+		ChatID:     subscription.ChatID,
+	}
+
+	if keywords != "" {
+		query := searchTerms(keywords)
+		if query == "" {
+			return ErrInvalidInput
+		}
+		insert.Keywords, insert.KeywordsQuery = &keywords, &query
+	}
+
+	if insert.LocalityID == 0 {
+		id, err := s.storage.SelectCountryLocalityID(ctx)
+		if err != nil {
+			return err
+		}
+		insert.LocalityID = id
+	}
+
+	// sorted unique ids, so subscriptions with the same set of categories are detected as duplicates
+	cids := make([]uuid.UUID, 0, len(subscription.CategoryIDs))
+	for _, cid := range subscription.CategoryIDs {
+		i := sort.Search(len(cids), func(i int) bool { return bytes.Compare(cids[i][:], cid[:]) >= 0 })
+		if i < len(cids) && cids[i] == cid {
+			continue
+		}
+		cids = append(cids[:i], append([]uuid.UUID{cid}, cids[i:]...)...)
+	}
+
+	insert.CategoryIDs = cids
+	err := s.storage.InsertSubscription(ctx, insert)
 
 	if errors.Is(err, storage.ErrUniqueViolation) {
 		return ErrAlreadyExists
@@ -187,6 +397,19 @@ func (s *Service) UserSubscriptions(ctx context.Context, userID uuid.UUID) ([]Us
 	if err != nil {
 		return nil, err
 	}
+	return userSubscriptions(ss), nil
+}
+
+// ChatSubscriptions returns subscriptions of specific group chat.
+func (s *Service) ChatSubscriptions(ctx context.Context, chatID int64) ([]UserSubscription, error) {
+	ss, err := s.storage.SelectSubscriptionsByChat(ctx, chatID)
+	if err != nil {
+		return nil, err
+	}
+	return userSubscriptions(ss), nil
+}
+
+func userSubscriptions(ss []*storage.SubscriptionValue) []UserSubscription {
 	subscriptions := make([]UserSubscription, 0, len(ss))
 	for _, subscription := range ss {
 		s := UserSubscription{
@@ -194,10 +417,13 @@ func (s *Service) UserSubscriptions(ctx context.Context, userID uuid.UUID) ([]Us
 			CreatorID: subscription.CreatorID,
 			CreatedAt: subscription.CreatedAt,
 		}
+		if subscription.Keywords != nil {
+			s.Keywords = *subscription.Keywords
+		}
 		s.localize(subscription)
 		subscriptions = append(subscriptions, s)
 	}
-	return subscriptions, nil
+	return subscriptions
 }
 
 func (h *UserHelp) localize(help *storage.Help) {
@@ -223,19 +449,38 @@ func (h *UserHelp) localize(help *storage.Help) {
 }
 
 func (us *UserSubscription) localize(subscription *storage.SubscriptionValue) {
+	categories := make([]string, 0, len(subscription.Categories))
 	switch subscription.Language {
 	case "UA":
-		us.Category, us.Locality = subscription.CategoryNameUA, subscription.LocalityPublicNameUA
+		us.Locality = subscription.LocalityPublicNameUA
+		for _, category := range subscription.Categories {
+			categories = append(categories, category.NameUA)
+		}
 	case "RU":
-		us.Category, us.Locality = subscription.CategoryNameRU, subscription.LocalityPublicNameRU
+		us.Locality = subscription.LocalityPublicNameRU
+		for _, category := range subscription.Categories {
+			categories = append(categories, category.NameRU)
+		}
 	case "EN":
-		us.Category, us.Locality = subscription.CategoryNameEN, subscription.LocalityPublicNameEN
+		us.Locality = subscription.LocalityPublicNameEN
+		for _, category := range subscription.Categories {
+			categories = append(categories, category.NameEN)
+		}
 	}
+	us.Categories = categories
 }
 
-// DeleteHelp deletes specific help by helpID.
+// DeleteHelp deletes specific help by helpID, ErrNotFound is returned if it doesn't exist or is already deleted.
 func (s *Service) DeleteHelp(ctx context.Context, helpID uuid.UUID) error {
-	return s.storage.DeleteHelp(ctx, helpID)
+	err := s.storage.DeleteHelp(ctx, helpID)
+	if errors.Is(err, storage.ErrNotFound) {
+		return ErrNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	return s.unpublishHelp(ctx, helpID)
 }
 
 // DeleteSubscription deletes specific subscription by helpID.
@@ -245,40 +490,156 @@ func (s *Service) DeleteSubscription(ctx context.Context, subscriptionID uuid.UU
 
 // NewHelp creates new help.
 func (s *Service) NewHelp(ctx context.Context, help NewHelp) error {
+	err := validateAttachments(help.Attachments)
+	if err != nil {
+		return err
+	}
+
+	err = help.Details.validate()
+	if err != nil {
+		return err
+	}
+
+	err = help.Contact.validate()
+	if err != nil {
+		return err
+	}
+
+	err = s.validateOrganisation(ctx, help.CreatorID, help.OrganisationID)
+	if err != nil {
+		return err
+	}
+
+	// suspicious helps are held for moderation
+	score, reasons, err := s.checkContent(ctx, help)
+	if err != nil {
+		return err
+	}
+
+	status := HelpStatusActive
+	if score >= SuspiciousScore {
+		status = HelpStatusPending
+	}
+
+	// duplicates are kept but hidden behind the original in search and not announced
+	var duplicateOf *uuid.UUID
+	dup, err := s.duplicateHelp(ctx, help, uuid.Nil)
+	switch {
+	case err == nil:
+		duplicateOf = &dup.ID
+	case !errors.Is(err, ErrNotFound):
+		return err
+	}
+
 	helpID, err := s.storage.InsertHelp(ctx, &storage.HelpInsert{
-		CreatorID:   help.CreatorID,
-		CategoryIDs: help.CategoryIDs,
-		LocalityID:  help.LocalityID,
-		Description: help.Description,
+		CreatorID:      help.CreatorID,
+		CategoryIDs:    help.CategoryIDs,
+		LocalityID:     help.LocalityID,
+		Description:    help.Description,
+		Phones:         helpPhones(help),
+		DuplicateOf:    duplicateOf,
+		OrganisationID: help.OrganisationID,
+		HelpDetails:    help.Details.storage(),
+		HelpContact:    help.Contact.storage(),
+		HelpModeration: storage.HelpModeration{
+			Status:      status,
+			SpamScore:   score,
+			SpamReasons: reasons,
+		},
 	})
 	if err != nil {
 		return err
 	}
 
+	err = s.saveAttachments(ctx, helpID, help.Attachments)
+	if err != nil {
+		return err
+	}
+
+	if status == HelpStatusPending {
+		return s.holdHelp(ctx, helpID, score, reasons)
+	}
+
+	if duplicateOf != nil {
+		return nil
+	}
+
 	helpValue, err := s.storage.SelectHelpByID(ctx, helpID)
 	if err != nil {
 		return err
 	}
 
-	subscriptions, err := s.storage.SelectSubscriptionsByLocalityCategories(ctx, help.LocalityID, help.CategoryIDs)
+	userHelp, err := s.userHelp(ctx, helpValue)
+	if err != nil {
+		return err
+	}
+
+	return s.announceHelp(ctx, userHelp, help.LocalityID, help.CategoryIDs)
+}
+
+// userHelp localizes single help with its attachments.
+func (s *Service) userHelp(ctx context.Context, help *storage.Help) (UserHelp, error) {
+	attachments, err := s.helpAttachments(ctx, help.ID)
+	if err != nil {
+		return UserHelp{}, err
+	}
+
+	h := UserHelp{
+		ID:               help.ID,
+		CreatorID:        help.CreatorID,
+		Description:      help.Description,
+		CreatedAt:        help.CreatedAt,
+		Attachments:      attachments,
+		AttachmentsCount: len(attachments),
+		Details:          helpDetails(help.HelpDetails),
+		HasContact:       !helpContact(help.HelpContact).Empty(),
+		Organisation:     helpOrganisation(help.HelpOrganisation),
+		Reputation:       helpReputation(help.HelpReputation),
+	}
+	h.localize(help)
+	return h, nil
+}
+
+// announceHelp posts active help to channels and notifies subscribers.
+func (s *Service) announceHelp(ctx context.Context, userHelp UserHelp, localityID int, categoryIDs []uuid.UUID) error {
+	err := s.publishHelp(ctx, userHelp, localityID)
+	if err != nil {
+		return err
+	}
+
+	categories, err := s.GetCategories(ctx)
+	if err != nil {
+		return err
+	}
+
+	// subscriptions on parent categories match helps in any of their subcategories
+	cids := categories.WithAncestors(categoryIDs)
+	subscriptions, err := s.storage.SelectSubscriptionsByLocalityCategories(ctx, localityID, cids)
+	if err != nil {
+		return err
+	}
+
+	keywordSubscriptions, err := s.storage.SelectKeywordSubscriptions(ctx, userHelp.ID, localityID, cids)
 	if err != nil {
 		return err
 	}
 
-	subscriptionMessages := make([]SubscriptionMessage, 0, len(subscriptions))
+	subscriptions = uniqueChats(append(subscriptions, keywordSubscriptions...))
+
+	var (
+		subscriptionMessages = make([]SubscriptionMessage, 0, len(subscriptions))
+		now                  = time.Now()
+	)
 
 	for _, subscription := range subscriptions {
-		u := UserHelp{
-			ID:          helpValue.ID,
-			CreatorID:   helpValue.CreatorID,
-			Description: helpValue.Description,
-			CreatedAt:   helpValue.CreatedAt,
-		}
-		u.localize(helpValue)
-		subscriptionMessages = append(subscriptionMessages, SubscriptionMessage{
-			UserHelp: u,
-			ChatID:   subscription.ChatID,
-		})
+		msg, err := s.notifyOrQueue(ctx, subscription, userHelp, now)
+		if err != nil {
+			return err
+		}
+
+		if msg != nil {
+			subscriptionMessages = append(subscriptionMessages, *msg)
+		}
 	}
 
 	go s.notifySubscriptions(subscriptionMessages)
@@ -286,28 +647,55 @@ func (s *Service) NewHelp(ctx context.Context, help NewHelp) error {
 	return nil
 }
 
+// uniqueChats keeps the first subscription of every chat, so a chat is notified about a help once.
+func uniqueChats(subscriptions []*storage.SubscriptionValue) []*storage.SubscriptionValue {
+	var (
+		seen   = make(map[int64]bool, len(subscriptions))
+		unique = subscriptions[:0]
+	)
+
+	for _, sub := range subscriptions {
+		chatID := sub.ChatID
+		if sub.GroupChatID != nil {
+			chatID = *sub.GroupChatID
+		}
+
+		if !seen[chatID] {
+			seen[chatID] = true
+			unique = append(unique, sub)
+		}
+	}
+	return unique
+}
+
 func (s *Service) notifySubscriptions(subscriptionMessages []SubscriptionMessage) {
 	s.subscriptionsMessageCh <- subscriptionMessages
 }
 
-// UserHelps returns user's helps.
-func (s *Service) UserHelps(ctx context.Context, userID uuid.UUID) ([]UserHelp, error) {
-	hs, err := s.storage.SelectHelpsByUser(ctx, userID)
+// HelpByID returns help by its id.
+func (s *Service) HelpByID(ctx context.Context, helpID uuid.UUID) (UserHelp, error) {
+	help, err := s.storage.SelectHelpByID(ctx, helpID)
 	if err != nil {
-		return nil, err
-	}
-	helps := make([]UserHelp, 0, len(hs))
-	for _, help := range hs {
-		h := UserHelp{
-			ID:          help.ID,
-			CreatorID:   help.CreatorID,
-			Description: help.Description,
-			CreatedAt:   help.CreatedAt,
+		if errors.Is(err, storage.ErrNotFound) {
+			return UserHelp{}, ErrNotFound
 		}
-		h.localize(help)
-		helps = append(helps, h)
+		return UserHelp{}, err
 	}
-	return helps, nil
+
+	if help.DeletedAt != nil {
+		return UserHelp{}, ErrNotFound
+	}
+
+	return s.userHelp(ctx, help)
+}
+
+// UserHelps returns a page of user's helps after the cursor.
+func (s *Service) UserHelps(ctx context.Context, userID uuid.UUID, after *Cursor, limit int) (HelpPage, error) {
+	hs, err := s.storage.SelectHelpsByUser(ctx, userID, storagePage(after, limit))
+	if err != nil {
+		return HelpPage{}, err
+	}
+	return helpPage(hs, limit), nil
 }
 
 func (s *Service) expiredHelps(ctx context.Context, after time.Time) ([]UserHelp, error) {
@@ -343,37 +731,270 @@ func (s *Service) GetCategories(ctx context.Context) (Categories, error) {
 	var categories = make(Categories, 0, len(cs))
 	for _, c := range cs {
 		categories = append(categories, Category{
-			ID:     c.ID,
-			NameUA: c.NameUA,
-			NameRU: c.NameRU,
-			NameEN: c.NameEN,
+			ID:       c.ID,
+			NameUA:   c.NameUA,
+			NameRU:   c.NameRU,
+			NameEN:   c.NameEN,
+			ParentID: c.ParentID,
+			Position: c.Position,
+			Hidden:   c.Hidden,
 		})
 	}
 
 	return categories, nil
 }
 
+// NewCategory creates new category at the end of the list.
+func (s *Service) NewCategory(ctx context.Context, nameUA, nameRU, nameEN string) (uuid.UUID, error) {
+	if nameUA == "" || nameRU == "" || nameEN == "" {
+		return uuid.UUID{}, ErrInvalidInput
+	}
+
+	cid, err := s.storage.InsertCategory(ctx, &storage.CategoryInsert{
+		NameUA: nameUA,
+		NameRU: nameRU,
+		NameEN: nameEN,
+	})
+	if err != nil {
+		if errors.Is(err, storage.ErrUniqueViolation) {
+			return uuid.UUID{}, ErrAlreadyExists
+		}
+		return uuid.UUID{}, err
+	}
+
+	s.notifyCategoriesUpdated()
+	return cid, nil
+}
+
+// RenameCategory updates category names in all languages.
+func (s *Service) RenameCategory(ctx context.Context, cid uuid.UUID, nameUA, nameRU, nameEN string) error {
+	if nameUA == "" || nameRU == "" || nameEN == "" {
+		return ErrInvalidInput
+	}
+
+	err := s.storage.UpdateCategoryNames(ctx, &storage.Category{
+		ID:     cid,
+		NameUA: nameUA,
+		NameRU: nameRU,
+		NameEN: nameEN,
+	})
+	if err != nil {
+		return categoryErr(err)
+	}
+
+	s.notifyCategoriesUpdated()
+	return nil
+}
+
+// HideCategory hides or shows category in bot keyboards.
+// Existing helps and subscriptions of hidden category are kept.
+func (s *Service) HideCategory(ctx context.Context, cid uuid.UUID, hidden bool) error {
+	err := s.storage.UpdateCategoryHidden(ctx, cid, hidden)
+	if err != nil {
+		return categoryErr(err)
+	}
+
+	s.notifyCategoriesUpdated()
+	return nil
+}
+
+// MoveCategory moves category to the given zero-based position.
+func (s *Service) MoveCategory(ctx context.Context, cid uuid.UUID, position int) error {
+	cs, err := s.storage.SelectCategories(ctx)
+	if err != nil {
+		return err
+	}
+
+	var (
+		ids   = make([]uuid.UUID, 0, len(cs))
+		found bool
+	)
+
+	for _, c := range cs {
+		if c.ID == cid {
+			found = true
+			continue
+		}
+		ids = append(ids, c.ID)
+	}
+
+	if !found {
+		return ErrNotFound
+	}
+
+	if position < 0 {
+		position = 0
+	}
+
+	if position > len(ids) {
+		position = len(ids)
+	}
+
+	ids = append(ids[:position], append([]uuid.UUID{cid}, ids[position:]...)...)
+
+	err = s.storage.UpdateCategoriesOrder(ctx, ids)
+	if err != nil {
+		return err
+	}
+
+	s.notifyCategoriesUpdated()
+	return nil
+}
+
+// SetCategoryParent makes category a subcategory of parentID, or a top level category if parentID is nil.
+func (s *Service) SetCategoryParent(ctx context.Context, cid uuid.UUID, parentID *uuid.UUID) error {
+	if parentID != nil {
+		categories, err := s.GetCategories(ctx)
+		if err != nil {
+			return err
+		}
+
+		// parent can't be the category itself or any of its subcategories
+		for _, id := range categories.WithDescendants([]uuid.UUID{cid}) {
+			if id == *parentID {
+				return ErrInvalidInput
+			}
+		}
+	}
+
+	err := s.storage.UpdateCategoryParent(ctx, cid, parentID)
+	if err != nil {
+		return categoryErr(err)
+	}
+
+	s.notifyCategoriesUpdated()
+	return nil
+}
+
+// MergeCategories moves helps, subscriptions and subcategories of category from into category to and deletes category from.
+func (s *Service) MergeCategories(ctx context.Context, from, to uuid.UUID) error {
+	if from == to {
+		return ErrInvalidInput
+	}
+
+	cs, err := s.storage.SelectCategories(ctx)
+	if err != nil {
+		return err
+	}
+
+	var found int
+	for _, c := range cs {
+		if c.ID == from || c.ID == to {
+			found++
+		}
+	}
+
+	if found != 2 {
+		return ErrNotFound
+	}
+
+	err = s.storage.MergeCategories(ctx, from, to)
+	if err != nil {
+		return categoryErr(err)
+	}
+
+	s.notifyCategoriesUpdated()
+	return nil
+}
+
+func (s *Service) notifyCategoriesUpdated() {
+	select {
+	case s.categoriesUpdatedCh <- struct{}{}:
+	default: // update is already pending
+	}
+}
+
+func categoryErr(err error) error {
+	if errors.Is(err, storage.ErrNotFound) {
+		return ErrNotFound
+	}
+	return err
+}
+
 func (c *Category) Translate(lang string) CategoryTranslated {
 	switch lang {
 	case "UA":
 		return CategoryTranslated{
-			ID:   c.ID,
-			Name: c.NameUA,
+			ID:       c.ID,
+			ParentID: c.ParentID,
+			Name:     c.NameUA,
 		}
 	case "RU":
 		return CategoryTranslated{
-			ID:   c.ID,
-			Name: c.NameRU,
+			ID:       c.ID,
+			ParentID: c.ParentID,
+			Name:     c.NameRU,
 		}
 	case "EN":
 		return CategoryTranslated{
-			ID:   c.ID,
-			Name: c.NameEN,
+			ID:       c.ID,
+			ParentID: c.ParentID,
+			Name:     c.NameEN,
 		}
 	}
 	return CategoryTranslated{}
 }
 
+// WithDescendants returns ids with all their subcategories.
+func (cs *Categories) WithDescendants(ids []uuid.UUID) []uuid.UUID {
+	var (
+		result = make([]uuid.UUID, 0, len(ids))
+		seen   = make(map[uuid.UUID]bool)
+		queue  = append([]uuid.UUID{}, ids...)
+	)
+
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		if seen[id] {
+			continue
+		}
+
+		seen[id] = true
+		result = append(result, id)
+		for _, c := range *cs {
+			if c.ParentID != nil && *c.ParentID == id {
+				queue = append(queue, c.ID)
+			}
+		}
+	}
+
+	return result
+}
+
+// WithAncestors returns ids with all their parent categories.
+func (cs *Categories) WithAncestors(ids []uuid.UUID) []uuid.UUID {
+	var (
+		result = make([]uuid.UUID, 0, len(ids))
+		seen   = make(map[uuid.UUID]bool)
+		parent = make(map[uuid.UUID]*uuid.UUID, len(*cs))
+	)
+
+	for _, c := range *cs {
+		parent[c.ID] = c.ParentID
+	}
+
+	for _, id := range ids {
+		for next := &id; next != nil && !seen[*next]; next = parent[*next] {
+			seen[*next] = true
+			result = append(result, *next)
+		}
+	}
+
+	return result
+}
+
+// Visible returns categories that are not hidden.
+func (cs *Categories) Visible() Categories {
+	visible := make(Categories, 0, len(*cs))
+	for _, c := range *cs {
+		if !c.Hidden {
+			visible = append(visible, c)
+		}
+	}
+	return visible
+}
+
 func (cs *Categories) Translate(lang string) CategoriesTranslated {
 	categoriesTranslated := make(CategoriesTranslated, 0, len(*cs))
 	for _, category := range *cs {
@@ -405,23 +1026,49 @@ func (ls *Localities) LocalityByNameRegion(name, region string) Locality {
 	return Locality{}
 }
 
-func (s *Service) HelpsByCategoryLocation(ctx context.Context, location int, category uuid.UUID) ([]UserHelp, error) {
-	hs, err := s.storage.SelectHelpsByLocalityCategory(ctx, location, category)
+// HelpsByCategoryLocation returns a page of helps in any of the query categories or their subcategories.
+func (s *Service) HelpsByCategoryLocation(ctx context.Context, q HelpQuery, after *Cursor, limit int) (HelpPage, error) {
+	hs, err := s.helpsByCategoryLocation(ctx, q, after, limit)
+	if err != nil {
+		return HelpPage{}, err
+	}
+	return helpPage(hs, limit), nil
+}
+
+// helpsByCategoryLocation selects helps of the page with one extra help, see storagePage.
+func (s *Service) helpsByCategoryLocation(ctx context.Context, q HelpQuery, after *Cursor, limit int) ([]*storage.Help, error) {
+	categories, err := s.GetCategories(ctx)
 	if err != nil {
 		return nil, err
 	}
-	helps := make([]UserHelp, 0, len(hs))
-	for _, help := range hs {
-		h := UserHelp{
-			ID:          help.ID,
-			CreatorID:   help.CreatorID,
-			Description: help.Description,
-			CreatedAt:   help.CreatedAt,
-		}
-		h.localize(help)
-		helps = append(helps, h)
+
+	f := storage.HelpFilter{
+		LocalityID:   q.LocalityID,
+		CategoryIDs:  categories.WithDescendants(q.CategoryIDs),
+		Radius:       q.Radius,
+		Sort:         string(q.Sort),
+		HasPhone:     q.HasPhone,
+		Language:     q.Language,
+		HasCapacity:  q.HasCapacity,
+		VerifiedOnly: q.VerifiedOnly,
 	}
-	return helps, nil
+
+	if f.Sort == "" {
+		f.Sort = string(SortNewest)
+	}
+
+	if q.PostedWithin > 0 {
+		createdAfter := time.Now().Add(-q.PostedWithin)
+		f.CreatedAfter = &createdAfter
+	}
+
+	if q.AvailableNow {
+		// compared with dates and hours as Kyiv wall clock
+		now := time.Now().In(kyivLocation)
+		f.AvailableAt = &now
+	}
+
+	return s.storage.SelectHelpsByLocalityCategories(ctx, f, storagePage(after, limit))
 }
 
 func (s *Service) GetActivityStats(ctx context.Context) (*ActivityStats, error) {
@@ -440,27 +1087,25 @@ func (s *Service) SubscriptionsCountByUser(ctx context.Context, user_id uuid.UUI
 	return s.storage.SelectSubscriptionsCountByUser(ctx, user_id)
 }
 
+func (s *Service) SubscriptionsCountByChat(ctx context.Context, chatID int64) (int, error) {
+	return s.storage.SelectSubscriptionsCountByChat(ctx, chatID)
+}
+
 func (s *Service) HelpsCountByUser(ctx context.Context, user_id uuid.UUID) (int, error) {
 	return s.storage.SelectHelpsCountByUser(ctx, user_id)
 }
 
-func (s *Service) HelpsBySubscription(ctx context.Context, sid uuid.UUID) ([]UserHelp, error) {
-	hs, err := s.storage.SelectHelpsBySubscription(ctx, sid)
+func (s *Service) HelpsBySubscription(ctx context.Context, sid uuid.UUID, after *Cursor, limit int) (HelpPage, error) {
+	sub, err := s.storage.SelectSubscriptionByID(ctx, sid)
 	if err != nil {
-		return nil, err
+		return HelpPage{}, err
 	}
-	helps := make([]UserHelp, 0, len(hs))
-	for _, help := range hs {
-		h := UserHelp{
-			ID:          help.ID,
-			CreatorID:   help.CreatorID,
-			Description: help.Description,
-			CreatedAt:   help.CreatedAt,
-		}
-		h.localize(help)
-		helps = append(helps, h)
+
+	if sub.Keywords != nil {
+		return s.SearchHelps(ctx, TextSearch{Text: *sub.Keywords, RegionID: sub.LocalityID, CategoryIDs: sub.CategoryIDs}, after, limit)
 	}
-	return helps, nil
+
+	return s.HelpsByCategoryLocation(ctx, HelpQuery{LocalityID: sub.LocalityID, CategoryIDs: sub.CategoryIDs}, after, limit)
 }
 
 func (s *Service) SubscriptionExists(ctx context.Context, sid uuid.UUID) (bool, error) {
@@ -0,0 +1,119 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/rvkinc/uasocial/internal/storage"
+)
+
+// User tiers
+const (
+	TierNew          = "NEW"
+	TierTrusted      = "TRUSTED"
+	TierOrganisation = "ORGANISATION"
+	TierAdmin        = "ADMIN"
+)
+
+type (
+	// LimitsConfig overrides built-in limits.
+	LimitsConfig struct {
+		// Tiers are keyed by lowercase tier name, zero limits fall back to the built-in ones
+		Tiers map[string]Limits `yaml:"tiers"`
+
+		// SubscriptionsPerGroup doesn't depend on the tier of a group admin
+		SubscriptionsPerGroup int `yaml:"subscriptions_per_group"`
+	}
+
+	Limits struct {
+		HelpsPerUser         int `yaml:"helps_per_user"`
+		SubscriptionsPerUser int `yaml:"subscriptions_per_user"`
+	}
+)
+
+// defaultLimits are used for the limits missing in config.
+var defaultLimits = map[string]Limits{ // nolint:gochecknoglobals
+	TierNew:          {HelpsPerUser: 2, SubscriptionsPerUser: 5},
+	TierTrusted:      {HelpsPerUser: 5, SubscriptionsPerUser: 10},
+	TierOrganisation: {HelpsPerUser: 50, SubscriptionsPerUser: 20},
+	TierAdmin:        {HelpsPerUser: 1000, SubscriptionsPerUser: 1000},
+}
+
+const defaultSubscriptionsPerGroup = 10
+
+// ValidTier reports whether tier is known.
+func ValidTier(tier string) bool {
+	_, ok := defaultLimits[tier]
+	return ok
+}
+
+// UserLimits returns limits of the tier, unknown tiers get limits of new users.
+func (s *Service) UserLimits(tier string) Limits {
+	if !ValidTier(tier) {
+		tier = TierNew
+	}
+
+	var (
+		limits   = s.config.Limits.Tiers[strings.ToLower(tier)]
+		defaults = defaultLimits[tier]
+	)
+
+	if limits.HelpsPerUser == 0 {
+		limits.HelpsPerUser = defaults.HelpsPerUser
+	}
+	if limits.SubscriptionsPerUser == 0 {
+		limits.SubscriptionsPerUser = defaults.SubscriptionsPerUser
+	}
+	return limits
+}
+
+// SubscriptionsPerGroup returns the number of subscriptions a group chat may have.
+func (s *Service) SubscriptionsPerGroup() int {
+	if n := s.config.Limits.SubscriptionsPerGroup; n > 0 {
+		return n
+	}
+	return defaultSubscriptionsPerGroup
+}
+
+// SetUserTier changes tier of the user by telegram id, ErrNotFound is returned if the user never started the bot.
+func (s *Service) SetUserTier(ctx context.Context, tgID int, tier string) error {
+	if !ValidTier(tier) {
+		return ErrInvalidInput
+	}
+
+	err := s.storage.UpdateUserTier(ctx, tgID, tier)
+	if errors.Is(err, storage.ErrNotFound) {
+		return ErrNotFound
+	}
+	return err
+}
+
+func (s *Service) isOwner(tgID int) bool {
+	return s.config.OwnerTgID != 0 && tgID == s.config.OwnerTgID
+}
+
+// ModeratorChatIDs returns chats held helps are sent to: the moderators chat if configured,
+// otherwise private chats of the owner and every admin.
+func (s *Service) ModeratorChatIDs(ctx context.Context) ([]int64, error) {
+	if s.config.ModeratorsChatID != 0 {
+		return []int64{s.config.ModeratorsChatID}, nil
+	}
+
+	chatIDs, err := s.storage.SelectUserChatIDsByTier(ctx, TierAdmin)
+	if err != nil {
+		return nil, err
+	}
+
+	// private chat id equals user id
+	owner := int64(s.config.OwnerTgID)
+	if owner == 0 {
+		return chatIDs, nil
+	}
+	for _, id := range chatIDs {
+		if id == owner {
+			return chatIDs, nil
+		}
+	}
+	return append(chatIDs, owner), nil
+}
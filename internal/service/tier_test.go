@@ -0,0 +1,98 @@
+package service
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/rvkinc/uasocial/internal/storage"
+)
+
+func TestUserLimits(t *testing.T) {
+	s := &Service{config: &Config{Limits: LimitsConfig{Tiers: map[string]Limits{
+		"new":     {HelpsPerUser: 3},
+		"trusted": {HelpsPerUser: 7, SubscriptionsPerUser: 12},
+		"TRUSTED": {HelpsPerUser: 100}, // tier names are lowercase in config
+	}}}}
+
+	tests := []struct {
+		tier string
+		want Limits
+	}{
+		{TierNew, Limits{HelpsPerUser: 3, SubscriptionsPerUser: 5}},
+		{TierTrusted, Limits{HelpsPerUser: 7, SubscriptionsPerUser: 12}},
+		{TierOrganisation, Limits{HelpsPerUser: 50, SubscriptionsPerUser: 20}},
+		{TierAdmin, Limits{HelpsPerUser: 1000, SubscriptionsPerUser: 1000}},
+		{"", Limits{HelpsPerUser: 3, SubscriptionsPerUser: 5}},
+		{"GOLD", Limits{HelpsPerUser: 3, SubscriptionsPerUser: 5}},
+		{"trusted", Limits{HelpsPerUser: 3, SubscriptionsPerUser: 5}}, // tiers are stored uppercase
+	}
+
+	for _, tt := range tests {
+		if got := s.UserLimits(tt.tier); got != tt.want {
+			t.Errorf("UserLimits(%q) = %+v, want %+v", tt.tier, got, tt.want)
+		}
+	}
+
+	if got := (&Service{config: new(Config)}).UserLimits(TierTrusted); got != defaultLimits[TierTrusted] {
+		t.Errorf("UserLimits() without config = %+v, want %+v", got, defaultLimits[TierTrusted])
+	}
+}
+
+func TestSubscriptionsPerGroup(t *testing.T) {
+	tests := []struct {
+		configured int
+		want       int
+	}{
+		{0, defaultSubscriptionsPerGroup},
+		{-1, defaultSubscriptionsPerGroup},
+		{25, 25},
+	}
+
+	for _, tt := range tests {
+		s := &Service{config: &Config{Limits: LimitsConfig{SubscriptionsPerGroup: tt.configured}}}
+		if got := s.SubscriptionsPerGroup(); got != tt.want {
+			t.Errorf("SubscriptionsPerGroup() with %d configured = %d, want %d", tt.configured, got, tt.want)
+		}
+	}
+}
+
+// adminStorage returns private chats of admins.
+type adminStorage struct {
+	storage.Interface
+	admins []int64
+}
+
+func (s adminStorage) SelectUserChatIDsByTier(context.Context, string) ([]int64, error) {
+	return s.admins, nil
+}
+
+func TestModeratorChatIDs(t *testing.T) {
+	tests := []struct {
+		name   string
+		config Config
+		admins []int64
+		want   []int64
+	}{
+		{"moderators chat", Config{ModeratorsChatID: -100, OwnerTgID: 1}, []int64{2}, []int64{-100}},
+		{"admins and owner", Config{OwnerTgID: 1}, []int64{2, 3}, []int64{2, 3, 1}},
+		{"owner is admin", Config{OwnerTgID: 2}, []int64{2, 3}, []int64{2, 3}},
+		{"no owner", Config{}, []int64{2}, []int64{2}},
+		{"owner only", Config{OwnerTgID: 1}, nil, []int64{1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := tt.config
+			s := &Service{config: &config, storage: adminStorage{admins: tt.admins}}
+
+			got, err := s.ModeratorChatIDs(context.Background())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ModeratorChatIDs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
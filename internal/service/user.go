@@ -0,0 +1,68 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/rvkinc/uasocial/internal/storage"
+)
+
+// UserPage is a page of users, the newest first. Users are paginated with the same cursor as helps.
+type UserPage struct {
+	Users []User
+	Next  *Cursor
+}
+
+// Users returns a page of registered users without their preferences.
+func (s *Service) Users(ctx context.Context, after *Cursor, limit int) (UserPage, error) {
+	us, err := s.storage.SelectUsers(ctx, storagePage(after, limit))
+	if err != nil {
+		return UserPage{}, err
+	}
+
+	var page UserPage
+	if len(us) > limit {
+		us = us[:limit]
+		last := us[len(us)-1]
+		page.Next = &Cursor{SortKey: last.SortKey, ID: last.ID}
+	}
+
+	page.Users = make([]User, 0, len(us))
+	for _, u := range us {
+		page.Users = append(page.Users, user(u))
+	}
+	return page, nil
+}
+
+// UserByID returns the user with preferences.
+func (s *Service) UserByID(ctx context.Context, uid uuid.UUID) (User, error) {
+	u, err := s.storage.SelectUserByID(ctx, uid)
+	if errors.Is(err, storage.ErrNotFound) {
+		return User{}, ErrNotFound
+	}
+	if err != nil {
+		return User{}, err
+	}
+
+	p, err := s.Preferences(ctx, uid)
+	if err != nil {
+		return User{}, err
+	}
+
+	result := user(u)
+	result.Preferences = p
+	return result, nil
+}
+
+func user(u *storage.User) User {
+	return User{
+		ID:        u.ID,
+		TgID:      u.TgID,
+		ChatID:    u.ChatID,
+		Name:      u.Name,
+		Language:  u.Language,
+		Tier:      u.Tier,
+		CreatedAt: u.CreatedAt,
+	}
+}
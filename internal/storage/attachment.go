@@ -0,0 +1,57 @@
+package storage
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type HelpAttachment struct {
+	HelpID    uuid.UUID `db:"help_id"`
+	Position  int       `db:"position"`
+	Type      string    `db:"type"`
+	FileID    string    `db:"file_id"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+const (
+	insertHelpAttachmentSQL = `
+insert into help_attachment (help_id, position, type, file_id, created_at)
+values ($1, $2, $3, $4, $5)`
+
+	selectHelpAttachmentsSQL = `
+select help_id, position, type, file_id, created_at from help_attachment where help_id = $1 order by position`
+
+	deleteHelpAttachmentsSQL = `delete from help_attachment where help_id = $1`
+)
+
+// InsertHelpAttachments stores attachments of the help in the given order.
+func (p *Postgres) InsertHelpAttachments(ctx context.Context, helpID uuid.UUID, attachments []*HelpAttachment) error {
+	tx, err := p.driver.BeginTxx(ctx, nil)
+	if err != nil {
+		return ErrFromCode(err)
+	}
+	defer tx.Rollback() // nolint:errcheck
+
+	now := time.Now()
+	for i, a := range attachments {
+		a.HelpID, a.Position, a.CreatedAt = helpID, i, now
+		_, err = tx.ExecContext(ctx, insertHelpAttachmentSQL, a.HelpID, a.Position, a.Type, a.FileID, a.CreatedAt)
+		if err != nil {
+			return ErrFromCode(err)
+		}
+	}
+
+	return ErrFromCode(tx.Commit())
+}
+
+func (p *Postgres) SelectHelpAttachments(ctx context.Context, helpID uuid.UUID) ([]*HelpAttachment, error) {
+	var attachments = make([]*HelpAttachment, 0)
+	return attachments, ErrFromCode(p.driver.SelectContext(ctx, &attachments, selectHelpAttachmentsSQL, helpID))
+}
+
+func (p *Postgres) DeleteHelpAttachments(ctx context.Context, helpID uuid.UUID) error {
+	_, err := p.driver.ExecContext(ctx, deleteHelpAttachmentsSQL, helpID)
+	return ErrFromCode(err)
+}
@@ -0,0 +1,53 @@
+package storage
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type HelpChannelPost struct {
+	HelpID    uuid.UUID `db:"help_id"`
+	ChatID    int64     `db:"chat_id"`
+	MessageID int       `db:"message_id"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+const (
+	insertHelpChannelPostSQL = `
+insert into help_channel_post (help_id, chat_id, message_id, created_at)
+values ($1, $2, $3, $4)
+    on conflict (help_id, chat_id) do update set message_id = $3, created_at = $4`
+
+	selectHelpChannelPostsSQL = `select help_id, chat_id, message_id, created_at from help_channel_post where help_id = $1`
+
+	deleteHelpChannelPostsSQL = `delete from help_channel_post where help_id = $1`
+
+	// helps not kept since $1 that are still posted to channels
+	selectExpiredPostedHelpsSQL = `
+select distinct h.id from help as h
+    join help_channel_post p on p.help_id = h.id
+where coalesce(h.updated_at, h.created_at) < $1 and h.deleted_at is null`
+)
+
+func (p *Postgres) InsertHelpChannelPost(ctx context.Context, post *HelpChannelPost) error {
+	post.CreatedAt = time.Now()
+	_, err := p.driver.ExecContext(ctx, insertHelpChannelPostSQL, post.HelpID, post.ChatID, post.MessageID, post.CreatedAt)
+	return ErrFromCode(err)
+}
+
+func (p *Postgres) SelectHelpChannelPosts(ctx context.Context, helpID uuid.UUID) ([]*HelpChannelPost, error) {
+	var posts = make([]*HelpChannelPost, 0)
+	return posts, ErrFromCode(p.driver.SelectContext(ctx, &posts, selectHelpChannelPostsSQL, helpID))
+}
+
+func (p *Postgres) DeleteHelpChannelPosts(ctx context.Context, helpID uuid.UUID) error {
+	_, err := p.driver.ExecContext(ctx, deleteHelpChannelPostsSQL, helpID)
+	return ErrFromCode(err)
+}
+
+func (p *Postgres) SelectExpiredPostedHelps(ctx context.Context, t time.Time) ([]uuid.UUID, error) {
+	var ids = make([]uuid.UUID, 0)
+	return ids, ErrFromCode(p.driver.SelectContext(ctx, &ids, selectExpiredPostedHelpsSQL, t))
+}
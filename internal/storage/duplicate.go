@@ -0,0 +1,48 @@
+package storage
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+type (
+	// DuplicateFilter describes a new or edited help to find near-duplicates of.
+	DuplicateFilter struct {
+		LocalityID  int
+		Description string
+		Phones      []string
+		CategoryIDs []uuid.UUID
+		Similarity  float64 // minimal trigram similarity of normalized descriptions
+		ExcludeID   uuid.UUID
+	}
+
+	DuplicateHelp struct {
+		ID         uuid.UUID `db:"id"`
+		CreatorID  uuid.UUID `db:"creator_id"`
+		Similarity float64   `db:"similarity"`
+	}
+)
+
+const (
+	// originals only, so duplicates always point to the help seekers see
+	selectDuplicateHelpSQL = `
+with q as (
+    select trim(regexp_replace(lower($2), '[^[:alnum:]]+', ' ', 'g')) as description
+)
+select h.id, h.creator_id, similarity(h.description_normalized, q.description)::float8 as similarity
+from help as h, q
+where h.locality_id = $1 and h.deleted_at is null and h.status = 'ACTIVE' and h.duplicate_of is null and h.id != $6
+  and ((h.description_normalized % q.description and similarity(h.description_normalized, q.description) >= $5)
+    or (h.phones && $3::text[] and h.category_ids && $4::uuid[]))
+order by similarity desc, h.created_at desc
+limit 1`
+)
+
+// SelectDuplicateHelp returns the most similar active help, ErrNotFound is returned if there is none.
+func (p *Postgres) SelectDuplicateHelp(ctx context.Context, f DuplicateFilter) (*DuplicateHelp, error) {
+	var dup = new(DuplicateHelp)
+	return dup, ErrFromCode(p.driver.GetContext(ctx, dup, selectDuplicateHelpSQL,
+		f.LocalityID, f.Description, pq.Array(f.Phones), pq.Array(f.CategoryIDs), f.Similarity, f.ExcludeID))
+}
@@ -0,0 +1,95 @@
+package storage
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// FeedbackRequest is a seeker to be asked whether they received the help.
+type FeedbackRequest struct {
+	HelpID      uuid.UUID `db:"help_id"`
+	UserID      uuid.UUID `db:"user_id"`
+	ChatID      int64     `db:"chat_id"`
+	Language    string    `db:"language"`
+	Description string    `db:"description"`
+}
+
+const (
+	// the first contact is kept, so the seeker is asked once per help
+	insertHelpFeedbackSQL = `
+insert into help_feedback (help_id, user_id, contacted_at) values ($1, $2, $3)
+    on conflict (help_id, user_id) do nothing`
+
+	// seekers aren't asked about helps deleted or held for moderation since they opened the contact
+	updateFeedbackAskedSQL = `
+update help_feedback as f set asked_at = $2
+from help h, app_user u
+where f.asked_at is null and f.contacted_at < $1 and h.id = f.help_id and u.id = f.user_id
+  and h.deleted_at is null and h.status = 'ACTIVE'
+returning f.help_id, f.user_id, u.chat_id, u.language, h.description`
+
+	updateFeedbackAnswerSQL = `
+update help_feedback set answer = $3, answered_at = $4
+where help_id = $1 and user_id = $2 and asked_at is not null and answer is null`
+
+	// the volunteer's counts are recomputed, every seeker votes once with their latest answer
+	// to any help of the volunteer, a report counts as a negative vote too
+	upsertUserReputationSQL = `
+insert into user_reputation as r (user_id, positive, negative, reports)
+select v.creator_id,
+    count(*) filter (where v.answer = 'YES'),
+    count(*) filter (where v.answer != 'YES'),
+    count(*) filter (where v.answer = 'REPORT')
+from (
+    select distinct on (f.user_id) h.creator_id, f.answer
+    from help_feedback f
+        join help h on h.id = f.help_id
+    where h.creator_id = (select creator_id from help where id = $1) and f.answer is not null
+    order by f.user_id, f.answered_at desc
+) v
+group by v.creator_id
+    on conflict (user_id) do update set
+        positive = excluded.positive,
+        negative = excluded.negative,
+        reports = excluded.reports`
+)
+
+// InsertHelpFeedback remembers that the user opened the contact of the help.
+func (p *Postgres) InsertHelpFeedback(ctx context.Context, helpID, uid uuid.UUID) error {
+	_, err := p.driver.ExecContext(ctx, insertHelpFeedbackSQL, helpID, uid, time.Now())
+	return ErrFromCode(err)
+}
+
+// UpdateFeedbackAsked marks seekers who opened contacts before the time as asked and returns them.
+func (p *Postgres) UpdateFeedbackAsked(ctx context.Context, contactedBefore time.Time) ([]*FeedbackRequest, error) {
+	var requests = make([]*FeedbackRequest, 0)
+	return requests, ErrFromCode(p.driver.SelectContext(ctx, &requests, updateFeedbackAskedSQL, contactedBefore, time.Now()))
+}
+
+// UpdateFeedbackAnswer saves the answer of the seeker and recounts the reputation of the volunteer,
+// returns ErrNotFound if the seeker wasn't asked or has already answered.
+func (p *Postgres) UpdateFeedbackAnswer(ctx context.Context, helpID, uid uuid.UUID, answer string) error {
+	tx, err := p.driver.BeginTxx(ctx, nil)
+	if err != nil {
+		return ErrFromCode(err)
+	}
+	defer tx.Rollback() // nolint:errcheck
+
+	res, err := tx.ExecContext(ctx, updateFeedbackAnswerSQL, helpID, uid, answer, time.Now())
+	if err != nil {
+		return ErrFromCode(err)
+	}
+
+	if err = errIfNoRows(res); err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, upsertUserReputationSQL, helpID)
+	if err != nil {
+		return ErrFromCode(err)
+	}
+
+	return ErrFromCode(tx.Commit())
+}
@@ -0,0 +1,58 @@
+package storage
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	upsertUserLocalitySQL = `
+insert into user_locality (user_id, locality_id, used_at)
+values ($1, $2, $3)
+    on conflict (user_id, locality_id) do update set used_at = $3`
+
+	selectRecentLocalitiesSQL = `
+select l1.id, l1.type, l1.public_name_ua, l3.public_name_ua as region_public_name_ua, 0 as leven from user_locality as ul
+    join locality as l1 on (ul.locality_id = l1.id)
+    join locality as l2 on (l1.parent_id = l2.id)
+    join locality as l3 on (l2.parent_id = l3.id)
+where ul.user_id = $1
+order by ul.used_at desc
+limit $2`
+
+	// equirectangular approximation is precise enough to find the nearest settlement
+	selectNearestLocalitySQL = `
+select l1.id, l1.type, l1.public_name_ua, l3.public_name_ua as region_public_name_ua, 0 as leven from locality as l1
+    join locality as l2 on (l1.parent_id = l2.id)
+    join locality as l3 on (l2.parent_id = l3.id)
+where l1.type != 'DISTRICT' and l1.type != 'STATE' and l1.type != 'COUNTRY'
+order by power(l1.lat - $1, 2) + power((l1.lng - $2) * cos(radians($1)), 2)
+limit 1`
+
+	selectCountryLocalityIDSQL = `select id from locality where type = 'COUNTRY' order by id limit 1`
+)
+
+// UpsertUserLocality marks locality as the most recently used by user.
+func (p *Postgres) UpsertUserLocality(ctx context.Context, uid uuid.UUID, localityID int) error {
+	_, err := p.driver.ExecContext(ctx, upsertUserLocalitySQL, uid, localityID, time.Now())
+	return ErrFromCode(err)
+}
+
+// SelectRecentLocalities returns localities recently used by user, the most recent first.
+func (p *Postgres) SelectRecentLocalities(ctx context.Context, uid uuid.UUID, limit int) ([]*LocalityRegion, error) {
+	var localities = make([]*LocalityRegion, 0)
+	return localities, ErrFromCode(p.driver.SelectContext(ctx, &localities, selectRecentLocalitiesSQL, uid, limit))
+}
+
+func (p *Postgres) SelectNearestLocality(ctx context.Context, lat, lng float64) (*LocalityRegion, error) {
+	var locality = new(LocalityRegion)
+	return locality, ErrFromCode(p.driver.GetContext(ctx, locality, selectNearestLocalitySQL, lat, lng))
+}
+
+func (p *Postgres) SelectCountryLocalityID(ctx context.Context) (int, error) {
+	var id int
+	err := p.driver.GetContext(ctx, &id, selectCountryLocalityIDSQL)
+	return id, ErrFromCode(err)
+}
@@ -0,0 +1,117 @@
+package storage
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type BannedPhrase struct {
+	Phrase    string    `db:"phrase"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+const (
+	selectPendingHelpsSQL = `
+select
+    h.id,
+    h.creator_id,
+    json_agg(json_build_object('name_ua', c.name_ua, 'name_ru', c.name_ru, 'name_en', c.name_en)) as categories,
+    l.public_name_ua as loc_public_name_ua,
+    l.public_name_ru as loc_public_name_ru,
+    l.public_name_en as loc_public_name_en,
+    u.language,
+    h.description,
+    h.created_at,
+    h.updated_at,
+    h.deleted_at,
+    h.status,
+    h.spam_score,
+    h.spam_reasons,
+    (select o.name from organisation o where o.id = h.organisation_id) as organisation_name,
+    coalesce((select o.verified from organisation o where o.id = h.organisation_id), false) as organisation_verified,
+    (select count(*) from help_attachment a where a.help_id = h.id)::int as attachments_count
+from help as h
+    join app_user u on h.creator_id = u.id
+    join locality l on h.locality_id = l.id
+    join category c on c.id = any(h.category_ids)
+where h.status = 'PENDING' and h.deleted_at is null
+group by h.id, u.language, l.public_name_ua, l.public_name_ru, l.public_name_en
+order by h.created_at
+limit $1`
+
+	// only pending helps are moderated, so the same help isn't approved or rejected twice
+	updateHelpStatusSQL = `update help set status = $2 where id = $1 and status = 'PENDING' and deleted_at is null`
+
+	// rejected helps are deleted in the same statement, so they never stay rejected but visible
+	rejectHelpSQL = `update help set status = 'REJECTED', deleted_at = $2 where id = $1 and status = 'PENDING' and deleted_at is null`
+
+	// active helps are held again when reported by seekers
+	holdHelpSQL = `update help set status = 'PENDING' where id = $1 and status = 'ACTIVE' and deleted_at is null`
+
+	// deleted helps are counted too, so deleting and posting again doesn't reset the velocity
+	selectHelpsCountByUserSinceSQL = `select count(*) from help where creator_id = $1 and created_at >= $2`
+
+	selectBannedPhrasesSQL = `select phrase, created_at from banned_phrase order by phrase`
+
+	insertBannedPhraseSQL = `insert into banned_phrase (phrase, created_at) values ($1, $2)`
+
+	deleteBannedPhraseSQL = `delete from banned_phrase where phrase = $1`
+)
+
+// SelectPendingHelps returns the oldest helps held for moderation.
+func (p *Postgres) SelectPendingHelps(ctx context.Context, limit int) ([]*Help, error) {
+	var helps = make([]*Help, 0)
+	return helps, ErrFromCode(p.driver.SelectContext(ctx, &helps, selectPendingHelpsSQL, limit))
+}
+
+// UpdateHelpStatus moderates pending help, ErrNotFound is returned if the help isn't pending.
+func (p *Postgres) UpdateHelpStatus(ctx context.Context, id uuid.UUID, status string) error {
+	res, err := p.driver.ExecContext(ctx, updateHelpStatusSQL, id, status)
+	if err != nil {
+		return ErrFromCode(err)
+	}
+	return errIfNoRows(res)
+}
+
+// RejectHelp rejects and deletes pending help, ErrNotFound is returned if the help isn't pending.
+func (p *Postgres) RejectHelp(ctx context.Context, id uuid.UUID) error {
+	res, err := p.driver.ExecContext(ctx, rejectHelpSQL, id, time.Now())
+	if err != nil {
+		return ErrFromCode(err)
+	}
+	return errIfNoRows(res)
+}
+
+// HoldHelp returns active help to moderation, ErrNotFound is returned if the help isn't active.
+func (p *Postgres) HoldHelp(ctx context.Context, id uuid.UUID) error {
+	res, err := p.driver.ExecContext(ctx, holdHelpSQL, id)
+	if err != nil {
+		return ErrFromCode(err)
+	}
+	return errIfNoRows(res)
+}
+
+func (p *Postgres) SelectHelpsCountByUserSince(ctx context.Context, uid uuid.UUID, since time.Time) (int, error) {
+	var count int
+	return count, ErrFromCode(p.driver.GetContext(ctx, &count, selectHelpsCountByUserSinceSQL, uid, since))
+}
+
+func (p *Postgres) SelectBannedPhrases(ctx context.Context) ([]*BannedPhrase, error) {
+	var phrases = make([]*BannedPhrase, 0)
+	return phrases, ErrFromCode(p.driver.SelectContext(ctx, &phrases, selectBannedPhrasesSQL))
+}
+
+func (p *Postgres) InsertBannedPhrase(ctx context.Context, phrase string) error {
+	_, err := p.driver.ExecContext(ctx, insertBannedPhraseSQL, phrase, time.Now())
+	return ErrFromCode(err)
+}
+
+func (p *Postgres) DeleteBannedPhrase(ctx context.Context, phrase string) error {
+	res, err := p.driver.ExecContext(ctx, deleteBannedPhraseSQL, phrase)
+	if err != nil {
+		return ErrFromCode(err)
+	}
+	return errIfNoRows(res)
+}
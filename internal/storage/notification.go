@@ -0,0 +1,189 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+type (
+	UserPreference struct {
+		UserID           uuid.UUID  `db:"user_id"`
+		NotificationMode string     `db:"notification_mode"`
+		QuietHoursFrom   *int       `db:"quiet_hours_from"`
+		QuietHoursTo     *int       `db:"quiet_hours_to"`
+		LastDigestAt     *time.Time `db:"last_digest_at"`
+		SearchRadius     int        `db:"search_radius"`
+		ShareContact     bool       `db:"share_contact"`
+		UpdatedAt        time.Time  `db:"updated_at"`
+
+		DefaultLocalityID         *int    `db:"default_locality_id"`
+		DefaultLocalityName       *string `db:"default_locality_name"`
+		DefaultLocalityRegionName *string `db:"default_locality_region_name"`
+	}
+
+	QueuedNotificationInsert struct {
+		UserID         uuid.UUID
+		SubscriptionID uuid.UUID
+		HelpID         uuid.UUID
+	}
+
+	QueuedNotification struct {
+		ID               uuid.UUID  `db:"id"`
+		UserID           uuid.UUID  `db:"user_id"`
+		SubscriptionID   uuid.UUID  `db:"subscription_id"`
+		HelpID           uuid.UUID  `db:"help_id"`
+		ChatID           int64      `db:"chat_id"`
+		Language         string     `db:"language"`
+		NotificationMode string     `db:"notification_mode"`
+		QuietHoursFrom   *int       `db:"quiet_hours_from"`
+		QuietHoursTo     *int       `db:"quiet_hours_to"`
+		LastDigestAt     *time.Time `db:"last_digest_at"`
+		CreatedAt        time.Time  `db:"created_at"`
+
+		// the queued help and the subscription it matched, so digests are built without extra queries
+		Help         Help              `db:"help"`
+		Subscription SubscriptionValue `db:"subscription"`
+	}
+)
+
+const (
+	defaultNotificationMode = "INSTANT"
+
+	selectUserPreferenceSQL = `
+select p.user_id,
+       p.notification_mode,
+       p.quiet_hours_from,
+       p.quiet_hours_to,
+       p.last_digest_at,
+       p.search_radius,
+       p.share_contact,
+       p.updated_at,
+       p.default_locality_id,
+       l1.public_name_ua as default_locality_name,
+       l3.public_name_ua as default_locality_region_name
+from user_preference as p
+    left join locality l1 on l1.id = p.default_locality_id
+    left join locality l2 on l2.id = l1.parent_id
+    left join locality l3 on l3.id = l2.parent_id
+where p.user_id = $1`
+
+	upsertUserPreferenceSQL = `
+insert into user_preference as p
+    (user_id, notification_mode, quiet_hours_from, quiet_hours_to, search_radius, default_locality_id, share_contact, updated_at)
+values ($1, $2, $3, $4, $5, $6, $7, $8)
+    on conflict (user_id) do update set
+        notification_mode = $2, quiet_hours_from = $3, quiet_hours_to = $4,
+        search_radius = $5, default_locality_id = $6, share_contact = $7, updated_at = $8`
+
+	// last digest time of users whose digests are sent, the time is $2
+	updateLastDigestsSQL = `
+insert into user_preference as p
+    (user_id, last_digest_at, updated_at)
+select unnest($1::uuid[]), $2, $2
+    on conflict (user_id) do update set last_digest_at = $2`
+
+	insertQueuedNotificationSQL = `
+insert into notification_queue
+    (id, user_id, subscription_id, help_id, created_at)
+values ($1, $2, $3, $4, $5)
+    on conflict (user_id, help_id) do nothing`
+
+	selectQueuedNotificationsSQL = `
+select q.id,
+       q.user_id,
+       q.subscription_id,
+       q.help_id,
+       u.chat_id,
+       u.language,
+       coalesce(p.notification_mode, 'INSTANT') as notification_mode,
+       p.quiet_hours_from,
+       p.quiet_hours_to,
+       p.last_digest_at,
+       q.created_at,
+       h.id as "help.id",
+       h.creator_id as "help.creator_id",
+       (select coalesce(json_agg(json_build_object('name_ua', c.name_ua, 'name_ru', c.name_ru, 'name_en', c.name_en) order by c.position), '[]')
+           from category c where c.id = any(h.category_ids)) as "help.categories",
+       hl.public_name_ua as "help.loc_public_name_ua",
+       hl.public_name_ru as "help.loc_public_name_ru",
+       hl.public_name_en as "help.loc_public_name_en",
+       hu.language as "help.language",
+       h.description as "help.description",
+       h.created_at as "help.created_at",
+       h.deleted_at as "help.deleted_at",
+       s.id as "subscription.id",
+       s.creator_id as "subscription.creator_id",
+       s.keywords as "subscription.keywords",
+       u.language as "subscription.language",
+       (select coalesce(json_agg(json_build_object('name_ua', c.name_ua, 'name_ru', c.name_ru, 'name_en', c.name_en) order by c.position), '[]')
+           from category c where c.id = any(s.category_ids)) as "subscription.categories",
+       sl.public_name_ua as "subscription.public_name_ua",
+       sl.public_name_ru as "subscription.public_name_ru",
+       sl.public_name_en as "subscription.public_name_en",
+       s.created_at as "subscription.created_at"
+from notification_queue as q
+    join app_user u on u.id = q.user_id
+    left join user_preference p on p.user_id = q.user_id
+    join help h on h.id = q.help_id
+    join app_user hu on hu.id = h.creator_id
+    join locality hl on hl.id = h.locality_id
+    join subscription s on s.id = q.subscription_id
+    join locality sl on sl.id = s.locality_id
+order by q.user_id, q.subscription_id, q.created_at`
+
+	deleteQueuedNotificationsSQL = `delete from notification_queue where id = any($1::uuid[])`
+)
+
+// SelectUserPreference returns user preference, or the default one if user has never changed it.
+func (p *Postgres) SelectUserPreference(ctx context.Context, uid uuid.UUID) (*UserPreference, error) {
+	var pref = new(UserPreference)
+	err := ErrFromCode(p.driver.GetContext(ctx, pref, selectUserPreferenceSQL, uid))
+	if errors.Is(err, ErrNotFound) {
+		return &UserPreference{UserID: uid, NotificationMode: defaultNotificationMode}, nil
+	}
+
+	return pref, err
+}
+
+func (p *Postgres) UpsertUserPreference(ctx context.Context, pref *UserPreference) error {
+	pref.UpdatedAt = time.Now()
+	_, err := p.driver.ExecContext(ctx, upsertUserPreferenceSQL,
+		pref.UserID, pref.NotificationMode, pref.QuietHoursFrom, pref.QuietHoursTo,
+		pref.SearchRadius, pref.DefaultLocalityID, pref.ShareContact, pref.UpdatedAt)
+	return ErrFromCode(err)
+}
+
+func (p *Postgres) InsertQueuedNotification(ctx context.Context, n *QueuedNotificationInsert) error {
+	_, err := p.driver.ExecContext(ctx, insertQueuedNotificationSQL, uuid.New(), n.UserID, n.SubscriptionID, n.HelpID, time.Now())
+	return ErrFromCode(err)
+}
+
+func (p *Postgres) SelectQueuedNotifications(ctx context.Context) ([]*QueuedNotification, error) {
+	var ns = make([]*QueuedNotification, 0)
+	return ns, ErrFromCode(p.driver.SelectContext(ctx, &ns, selectQueuedNotificationsSQL))
+}
+
+// CompleteDigests marks digests of the users as sent at the time and removes their queued notifications.
+func (p *Postgres) CompleteDigests(ctx context.Context, uids, queuedIDs []uuid.UUID, t time.Time) error {
+	tx, err := p.driver.BeginTxx(ctx, nil)
+	if err != nil {
+		return ErrFromCode(err)
+	}
+	defer tx.Rollback() // nolint:errcheck
+
+	_, err = tx.ExecContext(ctx, updateLastDigestsSQL, pq.Array(uids), t)
+	if err != nil {
+		return ErrFromCode(err)
+	}
+
+	_, err = tx.ExecContext(ctx, deleteQueuedNotificationsSQL, pq.Array(queuedIDs))
+	if err != nil {
+		return ErrFromCode(err)
+	}
+
+	return ErrFromCode(tx.Commit())
+}
@@ -0,0 +1,98 @@
+package storage
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type (
+	Organisation struct {
+		ID          uuid.UUID `db:"id"`
+		Name        string    `db:"name"`
+		Description string    `db:"description"`
+		Contact     string    `db:"contact"`
+		Verified    bool      `db:"verified"`
+		Members     int       `db:"members"`
+		CreatedAt   time.Time `db:"created_at"`
+	}
+
+	OrganisationInsert struct {
+		Name        string
+		Description string
+		Contact     string
+	}
+)
+
+const (
+	insertOrganisationSQL = `
+insert into organisation (id, name, description, contact, verified, created_at)
+values ($1, $2, $3, $4, false, $5)`
+
+	selectOrganisationsSQL = `
+select o.id, o.name, o.description, o.contact, o.verified, o.created_at,
+    (select count(*) from organisation_member m where m.organisation_id = o.id)::int as members
+from organisation as o
+order by o.name`
+
+	selectOrganisationsByUserSQL = `
+select o.id, o.name, o.description, o.contact, o.verified, o.created_at
+from organisation as o
+    join organisation_member m on m.organisation_id = o.id
+where m.user_id = $1
+order by o.name`
+
+	updateOrganisationVerifiedSQL = `update organisation set verified = $2 where id = $1`
+
+	// members are linked by telegram id, so they must have started the bot
+	insertOrganisationMemberSQL = `
+insert into organisation_member (organisation_id, user_id, created_at)
+select o.id, u.id, $3 from organisation as o, app_user as u where o.id = $1 and u.tg_id = $2`
+
+	deleteOrganisationMemberSQL = `
+delete from organisation_member
+where organisation_id = $1 and user_id = (select id from app_user where tg_id = $2)`
+)
+
+func (p *Postgres) InsertOrganisation(ctx context.Context, o *OrganisationInsert) (uuid.UUID, error) {
+	var id = uuid.New()
+	_, err := p.driver.ExecContext(ctx, insertOrganisationSQL, id, o.Name, o.Description, o.Contact, time.Now())
+	return id, ErrFromCode(err)
+}
+
+// SelectOrganisations returns all organisations with the number of their members.
+func (p *Postgres) SelectOrganisations(ctx context.Context) ([]*Organisation, error) {
+	var orgs = make([]*Organisation, 0)
+	return orgs, ErrFromCode(p.driver.SelectContext(ctx, &orgs, selectOrganisationsSQL))
+}
+
+func (p *Postgres) SelectOrganisationsByUser(ctx context.Context, uid uuid.UUID) ([]*Organisation, error) {
+	var orgs = make([]*Organisation, 0)
+	return orgs, ErrFromCode(p.driver.SelectContext(ctx, &orgs, selectOrganisationsByUserSQL, uid))
+}
+
+func (p *Postgres) UpdateOrganisationVerified(ctx context.Context, id uuid.UUID, verified bool) error {
+	res, err := p.driver.ExecContext(ctx, updateOrganisationVerifiedSQL, id, verified)
+	if err != nil {
+		return ErrFromCode(err)
+	}
+	return errIfNoRows(res)
+}
+
+// InsertOrganisationMember links user by telegram id, ErrNotFound is returned if there is no such user or organisation.
+func (p *Postgres) InsertOrganisationMember(ctx context.Context, orgID uuid.UUID, tgID int) error {
+	res, err := p.driver.ExecContext(ctx, insertOrganisationMemberSQL, orgID, tgID, time.Now())
+	if err != nil {
+		return ErrFromCode(err)
+	}
+	return errIfNoRows(res)
+}
+
+func (p *Postgres) DeleteOrganisationMember(ctx context.Context, orgID uuid.UUID, tgID int) error {
+	res, err := p.driver.ExecContext(ctx, deleteOrganisationMemberSQL, orgID, tgID)
+	if err != nil {
+		return ErrFromCode(err)
+	}
+	return errIfNoRows(res)
+}
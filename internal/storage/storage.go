@@ -28,27 +28,80 @@ type Interface interface {
 	MigrateUp() error
 
 	UpsertUser(context.Context, *User) (*User, error)
+	UpdateUserLanguage(ctx context.Context, uid uuid.UUID, lang string) error
+	UpdateUserTier(ctx context.Context, tgID int, tier string) error
+	SelectUsers(context.Context, Page) ([]*User, error)
+	SelectUserByID(context.Context, uuid.UUID) (*User, error)
+	SelectUserChatIDsByTier(ctx context.Context, tier string) ([]int64, error)
 	SelectLocalityRegions(context.Context, string) ([]*LocalityRegion, error)
+	SelectLocalityAncestors(context.Context, int) ([]*Locality, error)
+	SelectCountryLocalityID(context.Context) (int, error)
+	SelectNearestLocality(ctx context.Context, lat, lng float64) (*LocalityRegion, error)
+	SelectRecentLocalities(ctx context.Context, uid uuid.UUID, limit int) ([]*LocalityRegion, error)
+	UpsertUserLocality(ctx context.Context, uid uuid.UUID, localityID int) error
 	SelectCategories(context.Context) ([]*Category, error)
+	InsertCategory(context.Context, *CategoryInsert) (uuid.UUID, error)
+	UpdateCategoryNames(context.Context, *Category) error
+	UpdateCategoryHidden(context.Context, uuid.UUID, bool) error
+	UpdateCategoryParent(ctx context.Context, cid uuid.UUID, parentID *uuid.UUID) error
+	UpdateCategoriesOrder(context.Context, []uuid.UUID) error
+	MergeCategories(ctx context.Context, from, to uuid.UUID) error
 
 	InsertHelp(context.Context, *HelpInsert) (uuid.UUID, error)
 	SelectHelpByID(context.Context, uuid.UUID) (*Help, error)
-	SelectHelpsByUser(context.Context, uuid.UUID) ([]*Help, error)
-	SelectHelpsByLocalityCategory(context.Context, int, uuid.UUID) ([]*Help, error)
-	SelectHelpsBySubscription(ctx context.Context, sid uuid.UUID) ([]*Help, error)
+	SelectHelpsByUser(context.Context, uuid.UUID, Page) ([]*Help, error)
+	SelectHelpsByLocalityCategories(context.Context, HelpFilter, Page) ([]*Help, error)
+	SelectHelpsByText(context.Context, TextFilter, Page) ([]*Help, error)
 	SelectHelpsCountByUser(context.Context, uuid.UUID) (int, error)
 	DeleteHelp(ctx context.Context, uuid2 uuid.UUID) error
 	SelectExpiredHelps(context.Context, time.Time) ([]*Help, error)
 	KeepHelp(ctx context.Context, requestID uuid.UUID) error
+	UpdateHelp(context.Context, uuid.UUID, *HelpUpdate) error
+	SelectDuplicateHelp(context.Context, DuplicateFilter) (*DuplicateHelp, error)
+	InsertHelpChannelPost(context.Context, *HelpChannelPost) error
+	SelectHelpChannelPosts(context.Context, uuid.UUID) ([]*HelpChannelPost, error)
+	DeleteHelpChannelPosts(context.Context, uuid.UUID) error
+	SelectExpiredPostedHelps(context.Context, time.Time) ([]uuid.UUID, error)
+	InsertHelpAttachments(context.Context, uuid.UUID, []*HelpAttachment) error
+	SelectHelpAttachments(context.Context, uuid.UUID) ([]*HelpAttachment, error)
+	DeleteHelpAttachments(context.Context, uuid.UUID) error
+	SelectPendingHelps(ctx context.Context, limit int) ([]*Help, error)
+	UpdateHelpStatus(ctx context.Context, id uuid.UUID, status string) error
+	RejectHelp(context.Context, uuid.UUID) error
+	HoldHelp(context.Context, uuid.UUID) error
+	SelectHelpsCountByUserSince(ctx context.Context, uid uuid.UUID, since time.Time) (int, error)
+	SelectBannedPhrases(context.Context) ([]*BannedPhrase, error)
+	InsertBannedPhrase(context.Context, string) error
+	DeleteBannedPhrase(context.Context, string) error
+	InsertHelpFeedback(ctx context.Context, helpID, uid uuid.UUID) error
+	UpdateFeedbackAsked(ctx context.Context, contactedBefore time.Time) ([]*FeedbackRequest, error)
+	UpdateFeedbackAnswer(ctx context.Context, helpID, uid uuid.UUID, answer string) error
+
+	InsertOrganisation(context.Context, *OrganisationInsert) (uuid.UUID, error)
+	SelectOrganisations(context.Context) ([]*Organisation, error)
+	SelectOrganisationsByUser(context.Context, uuid.UUID) ([]*Organisation, error)
+	UpdateOrganisationVerified(ctx context.Context, id uuid.UUID, verified bool) error
+	InsertOrganisationMember(ctx context.Context, orgID uuid.UUID, tgID int) error
+	DeleteOrganisationMember(ctx context.Context, orgID uuid.UUID, tgID int) error
 
 	InsertSubscription(context.Context, *SubscriptionInsert) error
+	SelectSubscriptionByID(context.Context, uuid.UUID) (*SubscriptionValue, error)
 	SelectSubscriptionsByUser(context.Context, uuid.UUID) ([]*SubscriptionValue, error)
 	SelectSubscriptionsByLocalityCategories(context.Context, int, []uuid.UUID) ([]*SubscriptionValue, error)
+	SelectKeywordSubscriptions(ctx context.Context, helpID uuid.UUID, localityID int, cids []uuid.UUID) ([]*SubscriptionValue, error)
 	SelectSubscriptionsCountByUser(context.Context, uuid.UUID) (int, error)
+	SelectSubscriptionsByChat(context.Context, int64) ([]*SubscriptionValue, error)
+	SelectSubscriptionsCountByChat(context.Context, int64) (int, error)
 	DeleteSubscription(context.Context, uuid.UUID) error
 
 	SelectActivityStats(context.Context) (*ActivityStats, error)
 	SelectSubscriptionExists(context.Context, uuid.UUID) (bool, error)
+
+	SelectUserPreference(context.Context, uuid.UUID) (*UserPreference, error)
+	UpsertUserPreference(context.Context, *UserPreference) error
+	InsertQueuedNotification(context.Context, *QueuedNotificationInsert) error
+	SelectQueuedNotifications(context.Context) ([]*QueuedNotification, error)
+	CompleteDigests(ctx context.Context, uids, queuedIDs []uuid.UUID, t time.Time) error
 }
 
 type Postgres struct {
@@ -119,6 +172,19 @@ func ErrFromCode(pqerr error) error {
 	}
 }
 
+func errIfNoRows(res sql.Result) error {
+	n, err := res.RowsAffected()
+	if err != nil {
+		return ErrFromCode(err)
+	}
+
+	if n == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
 type (
 	User struct {
 		ID        uuid.UUID `db:"id"`
@@ -126,8 +192,10 @@ type (
 		ChatID    int64     `db:"chat_id"`
 		Name      string    `db:"name"`
 		Language  string    `db:"language"`
+		Tier      string    `db:"tier"`
 		CreatedAt time.Time `db:"created_at"`
 		UpdatedAt time.Time `db:"updated_at"`
+		SortKey   float64   `db:"sort_key"` // set by paginated selects
 	}
 
 	LocalityRegion struct {
@@ -138,9 +206,20 @@ type (
 		RegionName string `db:"region_public_name_ua"`
 	}
 
+	Locality struct {
+		ID       int    `db:"id"`
+		ParentID *int   `db:"parent_id"`
+		Type     string `db:"type"`
+		Name     string `db:"public_name_ua"`
+	}
+
 	Help struct {
 		ID                   uuid.UUID  `db:"id"`
 		CreatorID            uuid.UUID  `db:"creator_id"`
+		CreatorChatID        int64      `db:"creator_chat_id"` // set for a single help
+		LocalityID           int        `db:"locality_id"`     // set for a single help
+		CategoryIDs          UUIDs      `db:"category_ids"`    // set for a single help
+		DuplicateOf          *uuid.UUID `db:"duplicate_of"`    // set for a single help
 		Categories           Categories `db:"categories"`
 		LocalityPublicNameEN string     `db:"loc_public_name_en"`
 		LocalityPublicNameRU string     `db:"loc_public_name_ru"`
@@ -150,6 +229,85 @@ type (
 		CreatedAt            time.Time  `db:"created_at"`
 		UpdatedAt            *time.Time `db:"updated_at"`
 		DeletedAt            *time.Time `db:"deleted_at"`
+		SortKey              float64    `db:"sort_key"` // set by paginated selects
+		Headline             *string    `db:"headline"` // description fragment with matched words, set by text search
+		AttachmentsCount     int        `db:"attachments_count"`
+		HelpDetails
+		HelpContact
+		HelpModeration
+		HelpOrganisation
+		HelpReputation
+	}
+
+	// HelpReputation counts feedback of seekers on all helps of the creator.
+	HelpReputation struct {
+		ReputationPositive int `db:"reputation_positive"`
+		ReputationNegative int `db:"reputation_negative"`
+	}
+
+	// HelpOrganisation is set if the help is posted on behalf of an organisation.
+	HelpOrganisation struct {
+		OrganisationName        *string `db:"organisation_name"`
+		OrganisationVerified    bool    `db:"organisation_verified"`
+		OrganisationDescription *string `db:"organisation_description"` // set for a single help
+		OrganisationContact     *string `db:"organisation_contact"`     // set for a single help
+	}
+
+	// HelpModeration is set by content checks of new helps.
+	HelpModeration struct {
+		Status      string         `db:"status"`
+		SpamScore   int            `db:"spam_score"`
+		SpamReasons pq.StringArray `db:"spam_reasons"`
+	}
+
+	// HelpContact is kept apart from the description, so it can be shown on request only.
+	HelpContact struct {
+		ContactPhone    *string `db:"contact_phone"`
+		ContactUsername *string `db:"contact_username"`
+		ContactShared   bool    `db:"contact_shared"` // creator shares contacts outside the bot
+	}
+
+	// HelpDetails are optional structured fields of a help.
+	HelpDetails struct {
+		Capacity      *int           `db:"capacity"`
+		CapacityUnit  *string        `db:"capacity_unit"`
+		AvailableFrom *time.Time     `db:"available_from"`
+		AvailableTo   *time.Time     `db:"available_to"`
+		Weekdays      pq.Int64Array  `db:"weekdays"` // 0 is Sunday
+		HoursFrom     *int           `db:"hours_from"`
+		HoursTo       *int           `db:"hours_to"`
+		Languages     pq.StringArray `db:"languages"`
+	}
+
+	// Page selects helps after the cursor ordered by sort key and id,
+	// the first page has no cursor.
+	Page struct {
+		AfterKey *float64
+		AfterID  uuid.UUID
+		Limit    int
+	}
+
+	// TextFilter selects helps by description, optionally within the region and its descendants.
+	TextFilter struct {
+		PrefixQuery     string // tsquery in the simple configuration
+		PlainQuery      string // text parsed by the russian configuration
+		RegionID        *int
+		CategoryIDs     []uuid.UUID // any category when empty
+		HeadlineOptions string
+	}
+
+	// HelpFilter selects helps in categories around the locality.
+	HelpFilter struct {
+		LocalityID   int
+		CategoryIDs  []uuid.UUID
+		Radius       int    // km
+		Sort         string // NEWEST, NEAREST or CONFIRMED
+		CreatedAfter *time.Time
+		HasPhone     bool
+		AvailableAt  *time.Time // Kyiv wall clock time helps must be available at
+		Language     string     // language volunteer speaks
+		HasCapacity  bool
+		VerifiedOnly bool // posted on behalf of verified organisations
 	}
 
 	HelpInsert struct {
@@ -157,27 +315,51 @@ type (
 		CategoryIDs []uuid.UUID
 		LocalityID  int
 		Description string
+		Phones      []string   // normalized phones of the contact and the description
+		DuplicateOf *uuid.UUID // help the new one is a near-duplicate of
+		// OrganisationID is set if posted on behalf of an organisation
+		OrganisationID *uuid.UUID
+		HelpDetails
+		HelpContact
+		HelpModeration
+	}
+
+	HelpUpdate struct {
+		Description string
+		Phones      []string
+		HelpDetails
+		HelpContact
+		HelpModeration
 	}
 
 	SubscriptionValue struct {
-		ID                   uuid.UUID `db:"id"`
-		CreatorID            uuid.UUID `db:"creator_id"`
-		CategoryID           uuid.UUID `db:"category_id"`
-		ChatID               int64     `db:"chat_id"`
-		Language             string    `db:"language"`
-		CategoryNameEN       string    `db:"name_en"`
-		CategoryNameRU       string    `db:"name_ru"`
-		CategoryNameUA       string    `db:"name_ua"`
-		LocalityPublicNameEN string    `db:"public_name_en"`
-		LocalityPublicNameRU string    `db:"public_name_ru"`
-		LocalityPublicNameUA string    `db:"public_name_ua"`
-		CreatedAt            time.Time `db:"created_at"`
+		ID                   uuid.UUID  `db:"id"`
+		CreatorID            uuid.UUID  `db:"creator_id"`
+		CategoryIDs          UUIDs      `db:"category_ids"`
+		LocalityID           int        `db:"locality_id"`
+		ChatID               int64      `db:"chat_id"`
+		GroupChatID          *int64     `db:"group_chat_id"` // set for group subscriptions
+		Keywords             *string    `db:"keywords"`      // set for keyword subscriptions
+		Language             string     `db:"language"`
+		Categories           Categories `db:"categories"`
+		NotificationMode     string     `db:"notification_mode"`
+		QuietHoursFrom       *int       `db:"quiet_hours_from"`
+		QuietHoursTo         *int       `db:"quiet_hours_to"`
+		LocalityPublicNameEN string     `db:"public_name_en"`
+		LocalityPublicNameRU string     `db:"public_name_ru"`
+		LocalityPublicNameUA string     `db:"public_name_ua"`
+		CreatedAt            time.Time  `db:"created_at"`
 	}
 
 	SubscriptionInsert struct {
-		CreatorID  uuid.UUID
-		CategoryID uuid.UUID
-		LocalityID int
+		CreatorID   uuid.UUID
+		CategoryIDs []uuid.UUID // sorted
+		LocalityID  int
+		ChatID      *int64 // group chat, nil for personal subscriptions
+
+		// keyword subscriptions match helps by description, categories are optional for them
+		Keywords      *string
+		KeywordsQuery *string // tsquery in the simple configuration
 	}
 
 	CategoryNames struct {
@@ -188,11 +370,23 @@ type (
 
 	Categories []CategoryNames
 
+	UUIDs []uuid.UUID
+
 	Category struct {
-		ID     uuid.UUID `db:"id"`
-		NameUA string    `db:"name_ua"`
-		NameRU string    `db:"name_ru"`
-		NameEN string    `db:"name_en"`
+		ID       uuid.UUID  `db:"id"`
+		NameUA   string     `db:"name_ua"`
+		NameRU   string     `db:"name_ru"`
+		NameEN   string     `db:"name_en"`
+		ParentID *uuid.UUID `db:"parent_id"`
+		Position int        `db:"position"`
+		Hidden   bool       `db:"hidden"`
+	}
+
+	CategoryInsert struct {
+		NameUA   string
+		NameRU   string
+		NameEN   string
+		ParentID *uuid.UUID
 	}
 
 	ActivityStats struct {
@@ -215,18 +409,27 @@ func (cs *Categories) Scan(src interface{}) error {
 	return nil
 }
 
+func (ids *UUIDs) Scan(src interface{}) error {
+	return pq.GenericArray{A: (*[]uuid.UUID)(ids)}.Scan(src)
+}
+
 const (
 	upsertUserSQL = `
 insert into app_user as u
 	(id, tg_id, chat_id, name, language, created_at, updated_at) 
 values ($1, $2, $3, $4, $5, $6, $7) 
-  	on conflict (tg_id) do update set name = $4 returning u.id`
+  	on conflict (tg_id) do update set name = $4 returning u.id, u.language, u.tier`
+
+	updateUserLanguageSQL = `update app_user set language = $2, updated_at = $3 where id = $1`
 
+	updateUserTierSQL = `update app_user set tier = $2, updated_at = $3 where tg_id = $1`
+
+	// names are compared case-insensitively, so typed names needn't be capitalized
 	selectLocalityRegionsSQL = `
-select l1.id, l1.type, l1.public_name_ua, l3.public_name_ua as region_public_name_ua, levenshtein(l1.name_ua, $1) as leven from locality as l1
+select l1.id, l1.type, l1.public_name_ua, l3.public_name_ua as region_public_name_ua, levenshtein(lower(l1.name_ua), lower($1)) as leven from locality as l1
     join locality as l2 on (l1.parent_id = l2.id)
     join locality as l3 on (l2.parent_id = l3.id)
-where levenshtein(l1.name_ua, $1) <= 1 or levenshtein(l1.name_ru, $1) <= 1
+where (levenshtein(lower(l1.name_ua), lower($1)) <= 1 or levenshtein(lower(l1.name_ru), lower($1)) <= 1)
   and l1.type != 'DISTRICT' and l1.type != 'STATE' and l1.type != 'COUNTRY'
 order by
     case l1.type
@@ -236,15 +439,30 @@ order by
         when 'VILLAGE' then 4
         end, leven`
 
+	selectLocalityAncestorsSQL = `
+with recursive ancestors as (
+    select id, parent_id, type, public_name_ua, 0 as depth from locality where id = $1
+    union
+    select l.id, l.parent_id, l.type, l.public_name_ua, a.depth + 1 from locality as l
+        join ancestors a on l.id = a.parent_id
+)
+select id, parent_id, type, public_name_ua from ancestors order by depth`
+
 	insertHelpSQL = `
 insert into help
-    (id, creator_id, category_ids, locality_id, description, created_at, updated_at, deleted_at) 
-values ($1, $2, $3, $4, $5, $6, null, null)`
+    (id, creator_id, category_ids, locality_id, description, created_at, updated_at, deleted_at,
+     capacity, capacity_unit, available_from, available_to, weekdays, hours_from, hours_to, languages,
+     contact_phone, contact_username, phones, duplicate_of, status, spam_score, spam_reasons, organisation_id)
+values ($1, $2, $3, $4, $5, $6, null, null, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22)`
 
 	selectHelpByIDSQL = `
 select
     h.id,
     h.creator_id,
+    u.chat_id as creator_chat_id,
+    h.locality_id,
+    h.category_ids,
+    h.duplicate_of,
     json_agg(json_build_object('name_ua', c.name_ua, 'name_ru', c.name_ru, 'name_en', c.name_en)) as categories,
     l.public_name_ua as loc_public_name_ua,
     l.public_name_ru as loc_public_name_ru,
@@ -253,35 +471,181 @@ select
     h.description,
     h.created_at,
     h.updated_at,
-    h.deleted_at
+    h.deleted_at,
+    h.status,
+    h.spam_score,
+    h.spam_reasons,
+    h.capacity,
+    h.capacity_unit,
+    h.available_from,
+    h.available_to,
+    h.weekdays,
+    h.hours_from,
+    h.hours_to,
+    h.languages,
+    h.contact_phone,
+    h.contact_username,
+    coalesce((select p.share_contact from user_preference p where p.user_id = h.creator_id), false) as contact_shared,
+    (select o.name from organisation o where o.id = h.organisation_id) as organisation_name,
+    coalesce((select o.verified from organisation o where o.id = h.organisation_id), false) as organisation_verified,
+    (select o.description from organisation o where o.id = h.organisation_id) as organisation_description,
+    (select o.contact from organisation o where o.id = h.organisation_id) as organisation_contact,
+    coalesce((select r.positive from user_reputation r where r.user_id = h.creator_id), 0) as reputation_positive,
+    coalesce((select r.negative from user_reputation r where r.user_id = h.creator_id), 0) as reputation_negative,
+    (select count(*) from help_attachment a where a.help_id = h.id)::int as attachments_count
 from help as h
          join app_user u on h.creator_id = u.id
          join locality l on h.locality_id = l.id
          join category c on c.id = any(h.category_ids)
 where h.id = $1
-group by h.id, u.language, l.public_name_ua, l.public_name_ru, l.public_name_en`
-
-	selectHelpsByLocalityCategorySQL = `
+group by h.id, u.chat_id, u.language, l.public_name_ua, l.public_name_ru, l.public_name_en`
+
+	// helps in the locality, in its descendants for districts and oblasts,
+	// in the neighbour localities of the same district for villages
+	// and in the settlements within $3 km when search radius is set
+	// sort key is ascending: negated timestamps for the newest first, distance in km for the nearest first,
+	// the newest are shifted by 6 hours per reputation point of the creator, up to 10 points either way,
+	// a vote between pages moves the help by 6 hours, so it may be repeated or skipped near the page boundary
+	selectHelpsByLocalityCategoriesSQL = `
+with recursive area as (
+    select n.id from locality as l
+        join locality n on (n.id = l.id or (n.parent_id = l.parent_id and
+             (l.type = 'VILLAGE' or l.type = 'URBAN' or l.type = 'SETTLEMENT')))
+    where l.id = $1
+    union
+    select l.id from locality as l
+        join area a on l.parent_id = a.id
+), nearby as (
+    select n.id from locality as l
+        join locality n on $3 > 0 and n.type != 'DISTRICT' and n.type != 'STATE' and n.type != 'COUNTRY' and
+             6371 * 2 * asin(sqrt(power(sin(radians(n.lat - l.lat) / 2), 2) +
+                 cos(radians(l.lat)) * cos(radians(n.lat)) * power(sin(radians(n.lng - l.lng) / 2), 2))) <= $3
+    where l.id = $1
+), matched as (
+    select h.id,
+        (case $4
+            when 'NEAREST' then coalesce(6371 * 2 * asin(sqrt(power(sin(radians(l.lat - o.lat) / 2), 2) +
+                cos(radians(o.lat)) * cos(radians(l.lat)) * power(sin(radians(l.lng - o.lng) / 2), 2))), 100000)
+            when 'CONFIRMED' then -extract(epoch from coalesce(h.updated_at, h.created_at))
+            else -extract(epoch from h.created_at) - 21600 * least(greatest(coalesce(r.positive - r.negative, 0), -10), 10)
+        end)::float8 as sort_key
+    from help as h
+        join locality l on l.id = h.locality_id
+        join locality o on o.id = $1
+        left join user_reputation r on r.user_id = h.creator_id
+    where (h.locality_id in (select id from area) or h.locality_id in (select id from nearby))
+      and h.category_ids && $2::uuid[] and h.deleted_at is null
+      and ($5::timestamp is null or h.created_at >= $5::timestamp)
+      and (not $6 or h.contact_phone is not null or cardinality(h.phones) > 0)
+      and ($10::timestamp is null or (
+          (h.available_from is null or h.available_from <= $10::date) and
+          (h.available_to is null or h.available_to >= $10::date) and
+          (h.weekdays is null or extract(dow from $10::timestamp)::int = any(h.weekdays)) and
+          (h.hours_from is null or case when h.hours_from <= h.hours_to
+              then extract(hour from $10::timestamp) >= h.hours_from and extract(hour from $10::timestamp) < h.hours_to
+              else extract(hour from $10::timestamp) >= h.hours_from or extract(hour from $10::timestamp) < h.hours_to end)))
+      and ($11::text = '' or $11::text = any(h.languages))
+      and (not $12 or h.capacity > 0) and h.status = 'ACTIVE'
+      and (not $13 or exists (select from organisation o where o.id = h.organisation_id and o.verified))
+      and (h.duplicate_of is null or not exists (select from help o where o.id = h.duplicate_of and o.deleted_at is null))
+)
 select
     h.id,
     h.creator_id,
 	json_agg(json_build_object('name_ua', c.name_ua, 'name_ru', c.name_ru, 'name_en', c.name_en)) as categories,
-    coalesce(reg_l.public_name_ua, l.public_name_ua) as loc_public_name_ua,
-    coalesce(reg_l.public_name_ru, l.public_name_ru) as loc_public_name_ru,
-    coalesce(reg_l.public_name_en, l.public_name_en) as loc_public_name_en,
+    l.public_name_ua as loc_public_name_ua,
+    l.public_name_ru as loc_public_name_ru,
+    l.public_name_en as loc_public_name_en,
     u.language,
     h.description,
     h.created_at,
     h.updated_at,
-    h.deleted_at
-from locality as l
-    left join locality reg_l on (l.parent_id = reg_l.parent_id and
-         (l.type = 'VILLAGE' or l.type = 'URBAN' or l.type = 'SETTLEMENT'))
-    join help h on coalesce(reg_l.id, l.id) = h.locality_id
-    join category c on c.id = any(h.category_ids) and $2 = any(h.category_ids)
+    h.deleted_at,
+    m.sort_key,
+    h.capacity,
+    h.capacity_unit,
+    h.available_from,
+    h.available_to,
+    h.weekdays,
+    h.hours_from,
+    h.hours_to,
+    h.languages,
+    h.contact_phone,
+    h.contact_username,
+    coalesce((select p.share_contact from user_preference p where p.user_id = h.creator_id), false) as contact_shared,
+    (select o.name from organisation o where o.id = h.organisation_id) as organisation_name,
+    coalesce((select o.verified from organisation o where o.id = h.organisation_id), false) as organisation_verified,
+    coalesce((select r.positive from user_reputation r where r.user_id = h.creator_id), 0) as reputation_positive,
+    coalesce((select r.negative from user_reputation r where r.user_id = h.creator_id), 0) as reputation_negative,
+    (select count(*) from help_attachment a where a.help_id = h.id)::int as attachments_count
+from matched as m
+    join help h on h.id = m.id
+    join locality l on l.id = h.locality_id
+    join category c on c.id = any(h.category_ids)
     join app_user u on h.creator_id = u.id
-where l.id = $1 and $2 = any(h.category_ids) and h.deleted_at is null
-group by h.id, u.language, l.public_name_ua, l.public_name_ru, l.public_name_en, loc_public_name_ua, loc_public_name_ru, loc_public_name_en`
+where $7::float8 is null or (m.sort_key, h.id) > ($7::float8, $8::uuid)
+group by h.id, m.sort_key, u.language, l.public_name_ua, l.public_name_ru, l.public_name_en
+order by m.sort_key, h.id
+limit $9`
+
+	// sort key is the negated rank, so the most relevant helps come first,
+	// the rank is scaled by 5% per reputation point of the creator, up to 10 points either way,
+	// like the newest sort a vote between pages may repeat or skip a help near the page boundary
+	selectHelpsByTextSQL = `
+with recursive region as (
+    select id from locality where id = $3
+    union
+    select l.id from locality as l
+        join region r on l.parent_id = r.id
+), q as (
+    select to_tsquery('simple', $1) || plainto_tsquery('russian', $2) as query
+), matched as (
+    select h.id,
+        (-ts_rank(h.search_vector, q.query) * (1 + 0.05 * least(greatest(coalesce(r.positive - r.negative, 0), -10), 10)))::float8 as sort_key
+    from help as h
+        cross join q
+        left join user_reputation r on r.user_id = h.creator_id
+    where h.search_vector @@ q.query and h.deleted_at is null and h.status = 'ACTIVE'
+      and ($3::int is null or h.locality_id in (select id from region))
+      and (coalesce(cardinality($8::uuid[]), 0) = 0 or h.category_ids && $8::uuid[])
+      and (h.duplicate_of is null or not exists (select from help o where o.id = h.duplicate_of and o.deleted_at is null))
+)
+select
+    h.id,
+    h.creator_id,
+    json_agg(json_build_object('name_ua', c.name_ua, 'name_ru', c.name_ru, 'name_en', c.name_en)) as categories,
+    l.public_name_ua as loc_public_name_ua,
+    l.public_name_ru as loc_public_name_ru,
+    l.public_name_en as loc_public_name_en,
+    u.language,
+    h.description,
+    h.created_at,
+    h.updated_at,
+    h.deleted_at,
+    m.sort_key,
+    ts_headline('simple', h.description, (select query from q), $4) as headline,
+    h.capacity,
+    h.capacity_unit,
+    h.available_from,
+    h.available_to,
+    h.weekdays,
+    h.hours_from,
+    h.hours_to,
+    h.languages,
+    (select o.name from organisation o where o.id = h.organisation_id) as organisation_name,
+    coalesce((select o.verified from organisation o where o.id = h.organisation_id), false) as organisation_verified,
+    coalesce((select r.positive from user_reputation r where r.user_id = h.creator_id), 0) as reputation_positive,
+    coalesce((select r.negative from user_reputation r where r.user_id = h.creator_id), 0) as reputation_negative,
+    (select count(*) from help_attachment a where a.help_id = h.id)::int as attachments_count
+from matched as m
+    join help h on h.id = m.id
+    join locality l on l.id = h.locality_id
+    join category c on c.id = any(h.category_ids)
+    join app_user u on h.creator_id = u.id
+where $5::float8 is null or (m.sort_key, h.id) > ($5::float8, $6::uuid)
+group by h.id, m.sort_key, u.language, l.public_name_ua, l.public_name_ru, l.public_name_en
+order by m.sort_key, h.id
+limit $7`
 
 	selectHelpsByUserSQL = `
 select
@@ -295,15 +659,31 @@ select
     h.description,
     h.created_at,
     h.updated_at,
-    h.deleted_at
+    h.deleted_at,
+    -extract(epoch from h.created_at)::float8 as sort_key,
+    h.status,
+    h.capacity,
+    h.capacity_unit,
+    h.available_from,
+    h.available_to,
+    h.weekdays,
+    h.hours_from,
+    h.hours_to,
+    h.languages,
+    (select o.name from organisation o where o.id = h.organisation_id) as organisation_name,
+    coalesce((select o.verified from organisation o where o.id = h.organisation_id), false) as organisation_verified,
+    (select count(*) from help_attachment a where a.help_id = h.id)::int as attachments_count
 from app_user as u
 	join help h on h.creator_id = u.id
 	join locality l on h.locality_id = l.id
 	join category c on c.id = any(h.category_ids)
-where u.id = $1 and h.deleted_at is null 
-group by h.id, u.language, l.public_name_ua, l.public_name_ru, l.public_name_en`
+where u.id = $1 and h.deleted_at is null
+  and ($2::float8 is null or (-extract(epoch from h.created_at)::float8, h.id) > ($2::float8, $3::uuid))
+group by h.id, u.language, l.public_name_ua, l.public_name_ru, l.public_name_en
+order by sort_key, h.id
+limit $4`
 
-	deleteHelpSQL = `update help set deleted_at = $2 where id = $1`
+	deleteHelpSQL = `update help set deleted_at = $2 where id = $1 and deleted_at is null`
 
 	selectExpiredHelps = `
 select
@@ -327,81 +707,210 @@ group by h.id, u.language, l.public_name_ua, l.public_name_ru, l.public_name_en`
 
 	keepHelpSQL = `update help set updated_at = $2 where id = $1`
 
+	updateHelpSQL = `
+update help set description = $2, phones = $3, updated_at = $4,
+    capacity = $5, capacity_unit = $6, available_from = $7, available_to = $8,
+    weekdays = $9, hours_from = $10, hours_to = $11, languages = $12,
+    contact_phone = $13, contact_username = $14,
+    -- a pending help stays pending until moderated, an active one is held again if the edit is suspicious
+    status = case when $15 = 'PENDING' then 'PENDING'::help_status else status end,
+    spam_score = $16, spam_reasons = $17
+where id = $1 and deleted_at is null`
+
 	insertSubscriptionSQL = `insert into subscription
-	    (id, creator_id, category_id, locality_id, created_at)
-	values ($1, $2, $3, $4, $5)`
+	    (id, creator_id, category_ids, locality_id, chat_id, keywords, keywords_query, created_at)
+	values ($1, $2, $3, $4, $5, $6, $7, $8)`
+
+	selectSubscriptionByIDSQL = `
+select s.id,
+	s.creator_id,
+	s.category_ids,
+	s.locality_id,
+	u.chat_id,
+	s.chat_id as group_chat_id,
+	s.keywords,
+	u.language,
+	(select coalesce(json_agg(json_build_object('name_ua', c.name_ua, 'name_ru', c.name_ru, 'name_en', c.name_en) order by c.position), '[]')
+	    from category c where c.id = any(s.category_ids)) as categories,
+	coalesce(p.notification_mode, 'INSTANT') as notification_mode,
+	p.quiet_hours_from,
+	p.quiet_hours_to,
+	l.public_name_ua,
+	l.public_name_ru,
+	l.public_name_en,
+	s.created_at
+from subscription as s
+    join app_user u on s.creator_id = u.id
+    join locality l on s.locality_id = l.id
+    left join user_preference p on p.user_id = s.creator_id
+where s.id = $1`
 
 	selectSubscriptionsByUserSQL = `
 select s.id,
 	s.creator_id,
-	s.category_id,
+	s.category_ids,
+	s.locality_id,
 	u.chat_id,
+	s.chat_id as group_chat_id,
+	s.keywords,
 	u.language,
-	c.name_ua,
-	c.name_ru,
-	c.name_en,
+	(select coalesce(json_agg(json_build_object('name_ua', c.name_ua, 'name_ru', c.name_ru, 'name_en', c.name_en) order by c.position), '[]')
+	    from category c where c.id = any(s.category_ids)) as categories,
+	coalesce(p.notification_mode, 'INSTANT') as notification_mode,
+	p.quiet_hours_from,
+	p.quiet_hours_to,
 	l.public_name_ua,
 	l.public_name_ru,
 	l.public_name_en,
 	s.created_at
 from app_user as u
     join subscription s on s.creator_id = u.id
-    join category c on c.id = s.category_id
     join locality l on s.locality_id = l.id
-where u.id = $1`
+    left join user_preference p on p.user_id = s.creator_id
+where u.id = $1 and s.chat_id is null`
 
+	selectSubscriptionsByChatSQL = `
+select s.id,
+	s.creator_id,
+	s.category_ids,
+	s.locality_id,
+	u.chat_id,
+	s.chat_id as group_chat_id,
+	s.keywords,
+	u.language,
+	(select coalesce(json_agg(json_build_object('name_ua', c.name_ua, 'name_ru', c.name_ru, 'name_en', c.name_en) order by c.position), '[]')
+	    from category c where c.id = any(s.category_ids)) as categories,
+	coalesce(p.notification_mode, 'INSTANT') as notification_mode,
+	p.quiet_hours_from,
+	p.quiet_hours_to,
+	l.public_name_ua,
+	l.public_name_ru,
+	l.public_name_en,
+	s.created_at
+from subscription as s
+    join app_user u on s.creator_id = u.id
+    join locality l on s.locality_id = l.id
+    left join user_preference p on p.user_id = s.creator_id
+where s.chat_id = $1
+order by s.created_at`
+
+	// subscriptions on the locality itself and on any of its district, oblast or country,
+	// one per chat the help is sent to
 	selectSubscriptionsByLocalityCategoriesSQL = `
-select distinct on (s.creator_id)
-       s.id,
-       s.creator_id,
-       s.category_id,
-       u.chat_id,
-       u.language,
-       c.name_ua,
-       c.name_ru,
-       c.name_en,
-       l.public_name_ua,
-       l.public_name_ru,
-       l.public_name_en,
-       s.created_at
+with recursive ancestors as (
+    select id, parent_id from locality where id = $1
+    union
+    select l.id, l.parent_id from locality as l
+        join ancestors a on l.id = a.parent_id
+)
+select distinct on (coalesce(s.chat_id, u.chat_id))
+    s.id,
+	s.creator_id,
+	s.category_ids,
+	s.locality_id,
+	u.chat_id,
+	s.chat_id as group_chat_id,
+	s.keywords,
+	u.language,
+	(select coalesce(json_agg(json_build_object('name_ua', c.name_ua, 'name_ru', c.name_ru, 'name_en', c.name_en) order by c.position), '[]')
+	    from category c where c.id = any(s.category_ids)) as categories,
+	coalesce(p.notification_mode, 'INSTANT') as notification_mode,
+	p.quiet_hours_from,
+	p.quiet_hours_to,
+	l.public_name_ua,
+	l.public_name_ru,
+	l.public_name_en,
+	s.created_at
 from app_user as u
-         join subscription s on s.creator_id = u.id
-         join category c on c.id = s.category_id
-         join locality l on s.locality_id = l.id
-where l.id = $1 and s.category_id = any($2::uuid[])`
+    join subscription s on s.creator_id = u.id
+    join locality l on s.locality_id = l.id
+    left join user_preference p on p.user_id = s.creator_id
+where s.locality_id in (select id from ancestors) and s.category_ids && $2::uuid[] and s.keywords is null`
+
+	// keyword subscriptions matching the help description, categories of the help are passed with their ancestors
+	selectKeywordSubscriptionsSQL = `
+with recursive ancestors as (
+    select id, parent_id from locality where id = $2
+    union
+    select l.id, l.parent_id from locality as l
+        join ancestors a on l.id = a.parent_id
+)
+select distinct on (coalesce(s.chat_id, u.chat_id))
+    s.id,
+	s.creator_id,
+	s.category_ids,
+	s.locality_id,
+	u.chat_id,
+	s.chat_id as group_chat_id,
+	s.keywords,
+	u.language,
+	(select coalesce(json_agg(json_build_object('name_ua', c.name_ua, 'name_ru', c.name_ru, 'name_en', c.name_en) order by c.position), '[]')
+	    from category c where c.id = any(s.category_ids)) as categories,
+	coalesce(p.notification_mode, 'INSTANT') as notification_mode,
+	p.quiet_hours_from,
+	p.quiet_hours_to,
+	l.public_name_ua,
+	l.public_name_ru,
+	l.public_name_en,
+	s.created_at
+from app_user as u
+    join subscription s on s.creator_id = u.id
+    join locality l on s.locality_id = l.id
+    join help h on h.id = $1
+    left join user_preference p on p.user_id = s.creator_id
+where s.keywords is not null and s.locality_id in (select id from ancestors)
+  and (cardinality(s.category_ids) = 0 or s.category_ids && $3::uuid[])
+  and h.search_vector @@ (to_tsquery('simple', s.keywords_query) || plainto_tsquery('russian', s.keywords))`
 
 	deleteSubscriptionSQL = `delete from subscription where id = $1`
 
-	selectCategoriesSQL = `select id, name_ua, name_en, name_ru from category`
+	selectCategoriesSQL = `select id, name_ua, name_en, name_ru, parent_id, position, hidden from category order by position, name_ua`
 
-	selectActivityStatsSQL = `select ( select count(*) from help ) as helps, ( select count(*) from subscription ) as subs`
+	insertCategorySQL = `
+insert into category
+    (id, name_ua, name_ru, name_en, parent_id, position, hidden)
+values ($1, $2, $3, $4, $5, (select coalesce(max(position) + 1, 0) from category), false)`
 
-	selectSubscriptionsCountByUserSQL = `select count(*) from subscription where creator_id = $1`
+	updateCategoryParentSQL = `update category set parent_id = $2 where id = $1`
 
-	selectHelpsCountByUserSQL = `select count(*) from help where creator_id = $1 and deleted_at is null`
+	mergeCategoryChildrenSQL = `update category set parent_id = $2 where parent_id = $1`
 
-	selectHelpsBySubscriptionSQL = `
-select
-    h.id,
-    h.creator_id,
-	json_agg(json_build_object('name_ua', c.name_ua, 'name_ru', c.name_ru, 'name_en', c.name_en)) as categories,
-    coalesce(reg_l.public_name_ua, l.public_name_ua) as loc_public_name_ua,
-    coalesce(reg_l.public_name_ru, l.public_name_ru) as loc_public_name_ru,
-    coalesce(reg_l.public_name_en, l.public_name_en) as loc_public_name_en,
-    u.language,
-    h.description,
-    h.created_at,
-    h.updated_at,
-    h.deleted_at
-from subscription as s
-	join locality l on l.id = s.locality_id
-    left join locality reg_l on (l.parent_id = reg_l.parent_id and
-         (l.type = 'VILLAGE' or l.type = 'URBAN' or l.type = 'SETTLEMENT'))
-    join help h on coalesce(reg_l.id, l.id) = h.locality_id and s.category_id = any(h.category_ids)
-    join category c on c.id = any(h.category_ids)
-    join app_user u on h.creator_id = u.id
-where s.id = $1 and h.deleted_at is null
-group by h.id, u.language, l.public_name_ua, l.public_name_ru, l.public_name_en, loc_public_name_ua, loc_public_name_ru, loc_public_name_en`
+	updateCategoryNamesSQL = `update category set name_ua = $2, name_ru = $3, name_en = $4 where id = $1`
+
+	updateCategoryHiddenSQL = `update category set hidden = $2 where id = $1`
+
+	updateCategoryPositionSQL = `update category set position = $2 where id = $1`
+
+	mergeHelpCategoriesSQL = `
+update help set category_ids = array(select distinct unnest(array_replace(category_ids, $1, $2)))
+where $1 = any(category_ids)`
+
+	// keeps one of the subscriptions that will have equal categories after the merge,
+	// preferring the one that is not changed by the merge
+	deleteMergedSubscriptionsSQL = `
+delete from subscription as s
+where $1 = any(s.category_ids) and exists(
+    select 1 from subscription as o
+    where o.id != s.id and o.locality_id = s.locality_id
+      and ((s.chat_id is null and o.chat_id is null and o.creator_id = s.creator_id) or o.chat_id = s.chat_id)
+      and o.keywords is not distinct from s.keywords
+      and array(select distinct x from unnest(array_replace(o.category_ids, $1, $2)) as x order by x) =
+          array(select distinct x from unnest(array_replace(s.category_ids, $1, $2)) as x order by x)
+      and ($1 != all(o.category_ids) or o.id < s.id))`
+
+	mergeSubscriptionCategoriesSQL = `
+update subscription set category_ids = array(select distinct x from unnest(array_replace(category_ids, $1, $2)) as x order by x)
+where $1 = any(category_ids)`
+
+	deleteCategorySQL = `delete from category where id = $1`
+
+	selectActivityStatsSQL = `select ( select count(*) from help where deleted_at is null and status = 'ACTIVE' ) as helps, ( select count(*) from subscription ) as subs`
+
+	selectSubscriptionsCountByUserSQL = `select count(*) from subscription where creator_id = $1 and chat_id is null`
+
+	selectSubscriptionsCountByChatSQL = `select count(*) from subscription where chat_id = $1`
+
+	selectHelpsCountByUserSQL = `select count(*) from help where creator_id = $1 and deleted_at is null`
 
 	selectSubscriptionExistsSQL = `select exists(select 1 from subscription where id = $1)`
 )
@@ -416,22 +925,45 @@ func (p *Postgres) UpsertUser(ctx context.Context, user *User) (*User, error) {
 	user.CreatedAt = now
 	user.UpdatedAt = now
 
-	var uid uuid.UUID
-	err := p.driver.GetContext(ctx, &uid, upsertUserSQL,
-		user.ID, user.TgID, user.ChatID, user.Name, user.Language, user.CreatedAt, user.UpdatedAt)
+	// existing user keeps its id, language and tier
+	err := p.driver.QueryRowxContext(ctx, upsertUserSQL,
+		user.ID, user.TgID, user.ChatID, user.Name, user.Language, user.CreatedAt, user.UpdatedAt).
+		Scan(&user.ID, &user.Language, &user.Tier)
 	if err != nil {
 		return nil, ErrFromCode(err)
 	}
 
-	user.ID = uid
 	return user, nil
 }
 
+func (p *Postgres) UpdateUserLanguage(ctx context.Context, uid uuid.UUID, lang string) error {
+	res, err := p.driver.ExecContext(ctx, updateUserLanguageSQL, uid, lang, time.Now())
+	if err != nil {
+		return ErrFromCode(err)
+	}
+	return errIfNoRows(res)
+}
+
+// UpdateUserTier sets tier of the user by telegram id, ErrNotFound is returned if the user never started the bot.
+func (p *Postgres) UpdateUserTier(ctx context.Context, tgID int, tier string) error {
+	res, err := p.driver.ExecContext(ctx, updateUserTierSQL, tgID, tier, time.Now())
+	if err != nil {
+		return ErrFromCode(err)
+	}
+	return errIfNoRows(res)
+}
+
 func (p *Postgres) SelectLocalityRegions(ctx context.Context, s string) ([]*LocalityRegion, error) {
 	var localities = make([]*LocalityRegion, 0)
 	return localities, ErrFromCode(p.driver.SelectContext(ctx, &localities, selectLocalityRegionsSQL, s))
 }
 
+// SelectLocalityAncestors returns locality followed by its district, oblast and country.
+func (p *Postgres) SelectLocalityAncestors(ctx context.Context, id int) ([]*Locality, error) {
+	var localities = make([]*Locality, 0)
+	return localities, ErrFromCode(p.driver.SelectContext(ctx, &localities, selectLocalityAncestorsSQL, id))
+}
+
 func (p *Postgres) InsertHelp(ctx context.Context, rq *HelpInsert) (uuid.UUID, error) {
 	var (
 		now = time.Now()
@@ -439,7 +971,9 @@ func (p *Postgres) InsertHelp(ctx context.Context, rq *HelpInsert) (uuid.UUID, e
 	)
 
 	_, err := p.driver.ExecContext(ctx, insertHelpSQL,
-		uid, rq.CreatorID, pq.Array(rq.CategoryIDs), rq.LocalityID, rq.Description, now)
+		uid, rq.CreatorID, pq.Array(rq.CategoryIDs), rq.LocalityID, rq.Description, now,
+		rq.Capacity, rq.CapacityUnit, rq.AvailableFrom, rq.AvailableTo, rq.Weekdays, rq.HoursFrom, rq.HoursTo, rq.Languages,
+		rq.ContactPhone, rq.ContactUsername, pq.Array(rq.Phones), rq.DuplicateOf, rq.Status, rq.SpamScore, rq.SpamReasons, rq.OrganisationID)
 
 	return uid, ErrFromCode(err)
 }
@@ -449,19 +983,32 @@ func (p *Postgres) SelectHelpByID(ctx context.Context, uid uuid.UUID) (*Help, er
 	return help, ErrFromCode(p.driver.GetContext(ctx, help, selectHelpByIDSQL, uid))
 }
 
-func (p *Postgres) SelectHelpsByLocalityCategory(ctx context.Context, localityID int, cid uuid.UUID) ([]*Help, error) {
+// SelectHelpsByLocalityCategories returns a page of helps around the locality matching the filter.
+func (p *Postgres) SelectHelpsByLocalityCategories(ctx context.Context, f HelpFilter, page Page) ([]*Help, error) {
 	var helps = make([]*Help, 0)
-	return helps, ErrFromCode(p.driver.SelectContext(ctx, &helps, selectHelpsByLocalityCategorySQL, localityID, cid))
+	return helps, ErrFromCode(p.driver.SelectContext(ctx, &helps, selectHelpsByLocalityCategoriesSQL,
+		f.LocalityID, pq.Array(f.CategoryIDs), f.Radius, f.Sort, f.CreatedAfter, f.HasPhone, page.AfterKey, page.AfterID, page.Limit,
+		f.AvailableAt, f.Language, f.HasCapacity, f.VerifiedOnly))
 }
 
-func (p *Postgres) SelectHelpsByUser(ctx context.Context, uid uuid.UUID) ([]*Help, error) {
+func (p *Postgres) SelectHelpsByText(ctx context.Context, f TextFilter, page Page) ([]*Help, error) {
 	var helps = make([]*Help, 0)
-	return helps, ErrFromCode(p.driver.SelectContext(ctx, &helps, selectHelpsByUserSQL, uid))
+	return helps, ErrFromCode(p.driver.SelectContext(ctx, &helps, selectHelpsByTextSQL,
+		f.PrefixQuery, f.PlainQuery, f.RegionID, f.HeadlineOptions, page.AfterKey, page.AfterID, page.Limit, pq.Array(f.CategoryIDs)))
 }
 
+func (p *Postgres) SelectHelpsByUser(ctx context.Context, uid uuid.UUID, page Page) ([]*Help, error) {
+	var helps = make([]*Help, 0)
+	return helps, ErrFromCode(p.driver.SelectContext(ctx, &helps, selectHelpsByUserSQL, uid, page.AfterKey, page.AfterID, page.Limit))
+}
+
+// DeleteHelp marks the help deleted, ErrNotFound is returned if it doesn't exist or is already deleted.
 func (p *Postgres) DeleteHelp(ctx context.Context, u uuid.UUID) error {
-	_, err := p.driver.ExecContext(ctx, deleteHelpSQL, u, time.Now())
-	return ErrFromCode(err)
+	res, err := p.driver.ExecContext(ctx, deleteHelpSQL, u, time.Now())
+	if err != nil {
+		return ErrFromCode(err)
+	}
+	return errIfNoRows(res)
 }
 
 func (p *Postgres) SelectExpiredHelps(ctx context.Context, t time.Time) ([]*Help, error) {
@@ -474,21 +1021,47 @@ func (p *Postgres) KeepHelp(ctx context.Context, requestID uuid.UUID) error {
 	return ErrFromCode(err)
 }
 
+// UpdateHelp replaces description, details and contact of the help.
+func (p *Postgres) UpdateHelp(ctx context.Context, id uuid.UUID, rq *HelpUpdate) error {
+	res, err := p.driver.ExecContext(ctx, updateHelpSQL, id, rq.Description, pq.Array(rq.Phones), time.Now(),
+		rq.Capacity, rq.CapacityUnit, rq.AvailableFrom, rq.AvailableTo, rq.Weekdays, rq.HoursFrom, rq.HoursTo, rq.Languages,
+		rq.ContactPhone, rq.ContactUsername, rq.Status, rq.SpamScore, rq.SpamReasons)
+	if err != nil {
+		return ErrFromCode(err)
+	}
+	return errIfNoRows(res)
+}
+
 func (p *Postgres) InsertSubscription(ctx context.Context, s *SubscriptionInsert) error {
-	_, err := p.driver.ExecContext(ctx, insertSubscriptionSQL, uuid.New(), s.CreatorID, s.CategoryID, s.LocalityID, time.Now())
+	_, err := p.driver.ExecContext(ctx, insertSubscriptionSQL, uuid.New(), s.CreatorID, pq.Array(s.CategoryIDs), s.LocalityID, s.ChatID, s.Keywords, s.KeywordsQuery, time.Now())
 	return ErrFromCode(err)
 }
 
+func (p *Postgres) SelectSubscriptionByID(ctx context.Context, sid uuid.UUID) (*SubscriptionValue, error) {
+	var sub = new(SubscriptionValue)
+	return sub, ErrFromCode(p.driver.GetContext(ctx, sub, selectSubscriptionByIDSQL, sid))
+}
+
 func (p *Postgres) SelectSubscriptionsByUser(ctx context.Context, uid uuid.UUID) ([]*SubscriptionValue, error) {
 	var sub = make([]*SubscriptionValue, 0)
 	return sub, ErrFromCode(p.driver.SelectContext(ctx, &sub, selectSubscriptionsByUserSQL, uid))
 }
 
+func (p *Postgres) SelectSubscriptionsByChat(ctx context.Context, chatID int64) ([]*SubscriptionValue, error) {
+	var sub = make([]*SubscriptionValue, 0)
+	return sub, ErrFromCode(p.driver.SelectContext(ctx, &sub, selectSubscriptionsByChatSQL, chatID))
+}
+
 func (p *Postgres) SelectSubscriptionsByLocalityCategories(ctx context.Context, l int, cids []uuid.UUID) ([]*SubscriptionValue, error) {
 	var sub = make([]*SubscriptionValue, 0)
 	return sub, ErrFromCode(p.driver.SelectContext(ctx, &sub, selectSubscriptionsByLocalityCategoriesSQL, l, pq.Array(cids)))
 }
 
+func (p *Postgres) SelectKeywordSubscriptions(ctx context.Context, helpID uuid.UUID, l int, cids []uuid.UUID) ([]*SubscriptionValue, error) {
+	var sub = make([]*SubscriptionValue, 0)
+	return sub, ErrFromCode(p.driver.SelectContext(ctx, &sub, selectKeywordSubscriptionsSQL, helpID, l, pq.Array(cids)))
+}
+
 func (p *Postgres) DeleteSubscription(ctx context.Context, sid uuid.UUID) error {
 	_, err := p.driver.ExecContext(ctx, deleteSubscriptionSQL, sid)
 	return ErrFromCode(err)
@@ -500,6 +1073,86 @@ func (p *Postgres) SelectCategories(ctx context.Context) ([]*Category, error) {
 	return cs, ErrFromCode(err)
 }
 
+func (p *Postgres) InsertCategory(ctx context.Context, c *CategoryInsert) (uuid.UUID, error) {
+	var uid = uuid.New()
+	_, err := p.driver.ExecContext(ctx, insertCategorySQL, uid, c.NameUA, c.NameRU, c.NameEN, c.ParentID)
+	return uid, ErrFromCode(err)
+}
+
+func (p *Postgres) UpdateCategoryNames(ctx context.Context, c *Category) error {
+	res, err := p.driver.ExecContext(ctx, updateCategoryNamesSQL, c.ID, c.NameUA, c.NameRU, c.NameEN)
+	if err != nil {
+		return ErrFromCode(err)
+	}
+
+	return errIfNoRows(res)
+}
+
+func (p *Postgres) UpdateCategoryHidden(ctx context.Context, cid uuid.UUID, hidden bool) error {
+	res, err := p.driver.ExecContext(ctx, updateCategoryHiddenSQL, cid, hidden)
+	if err != nil {
+		return ErrFromCode(err)
+	}
+
+	return errIfNoRows(res)
+}
+
+func (p *Postgres) UpdateCategoryParent(ctx context.Context, cid uuid.UUID, parentID *uuid.UUID) error {
+	res, err := p.driver.ExecContext(ctx, updateCategoryParentSQL, cid, parentID)
+	if err != nil {
+		return ErrFromCode(err)
+	}
+
+	return errIfNoRows(res)
+}
+
+// UpdateCategoriesOrder sets category positions according to their order in cids.
+func (p *Postgres) UpdateCategoriesOrder(ctx context.Context, cids []uuid.UUID) error {
+	tx, err := p.driver.BeginTxx(ctx, nil)
+	if err != nil {
+		return ErrFromCode(err)
+	}
+	defer tx.Rollback() // nolint:errcheck
+
+	for i, cid := range cids {
+		_, err = tx.ExecContext(ctx, updateCategoryPositionSQL, cid, i)
+		if err != nil {
+			return ErrFromCode(err)
+		}
+	}
+
+	return ErrFromCode(tx.Commit())
+}
+
+// MergeCategories moves all helps, subscriptions and subcategories of category from into category to
+// and deletes category from. Subscriptions that would become duplicates are dropped.
+func (p *Postgres) MergeCategories(ctx context.Context, from, to uuid.UUID) error {
+	tx, err := p.driver.BeginTxx(ctx, nil)
+	if err != nil {
+		return ErrFromCode(err)
+	}
+	defer tx.Rollback() // nolint:errcheck
+
+	for _, q := range []string{mergeHelpCategoriesSQL, deleteMergedSubscriptionsSQL, mergeSubscriptionCategoriesSQL, mergeCategoryChildrenSQL} {
+		_, err = tx.ExecContext(ctx, q, from, to)
+		if err != nil {
+			return ErrFromCode(err)
+		}
+	}
+
+	res, err := tx.ExecContext(ctx, deleteCategorySQL, from)
+	if err != nil {
+		return ErrFromCode(err)
+	}
+
+	err = errIfNoRows(res)
+	if err != nil {
+		return err
+	}
+
+	return ErrFromCode(tx.Commit())
+}
+
 func (p *Postgres) SelectActivityStats(ctx context.Context) (*ActivityStats, error) {
 	var stats = new(ActivityStats)
 	return stats, ErrFromCode(p.driver.GetContext(ctx, stats, selectActivityStatsSQL))
@@ -511,15 +1164,16 @@ func (p *Postgres) SelectSubscriptionsCountByUser(ctx context.Context, uid uuid.
 	return count, ErrFromCode(err)
 }
 
-func (p *Postgres) SelectHelpsCountByUser(ctx context.Context, uid uuid.UUID) (int, error) {
+func (p *Postgres) SelectSubscriptionsCountByChat(ctx context.Context, chatID int64) (int, error) {
 	var count int
-	err := p.driver.GetContext(ctx, &count, selectHelpsCountByUserSQL, uid)
+	err := p.driver.GetContext(ctx, &count, selectSubscriptionsCountByChatSQL, chatID)
 	return count, ErrFromCode(err)
 }
 
-func (p *Postgres) SelectHelpsBySubscription(ctx context.Context, sid uuid.UUID) ([]*Help, error) {
-	var helps = make([]*Help, 0)
-	return helps, ErrFromCode(p.driver.SelectContext(ctx, &helps, selectHelpsBySubscriptionSQL, sid))
+func (p *Postgres) SelectHelpsCountByUser(ctx context.Context, uid uuid.UUID) (int, error) {
+	var count int
+	err := p.driver.GetContext(ctx, &count, selectHelpsCountByUserSQL, uid)
+	return count, ErrFromCode(err)
 }
 
 func (p *Postgres) SelectSubscriptionExists(ctx context.Context, sid uuid.UUID) (bool, error) {
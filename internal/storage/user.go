@@ -0,0 +1,37 @@
+package storage
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// the newest users first, sort key is the negated registration timestamp
+	selectUsersSQL = `
+select id, tg_id, chat_id, name, language, tier, created_at, updated_at, -extract(epoch from created_at)::float8 as sort_key
+from app_user
+where $1::float8 is null or (-extract(epoch from created_at)::float8, id) > ($1::float8, $2::uuid)
+order by sort_key, id
+limit $3`
+
+	selectUserByIDSQL = `select id, tg_id, chat_id, name, language, tier, created_at, updated_at from app_user where id = $1`
+
+	selectUserChatIDsByTierSQL = `select chat_id from app_user where tier = $1`
+)
+
+func (p *Postgres) SelectUsers(ctx context.Context, page Page) ([]*User, error) {
+	var users = make([]*User, 0)
+	return users, ErrFromCode(p.driver.SelectContext(ctx, &users, selectUsersSQL, page.AfterKey, page.AfterID, page.Limit))
+}
+
+func (p *Postgres) SelectUserByID(ctx context.Context, uid uuid.UUID) (*User, error) {
+	var user User
+	return &user, ErrFromCode(p.driver.GetContext(ctx, &user, selectUserByIDSQL, uid))
+}
+
+// SelectUserChatIDsByTier returns private chats of users of the tier.
+func (p *Postgres) SelectUserChatIDsByTier(ctx context.Context, tier string) ([]int64, error) {
+	var chatIDs = make([]int64, 0)
+	return chatIDs, ErrFromCode(p.driver.SelectContext(ctx, &chatIDs, selectUserChatIDsByTierSQL, tier))
+}
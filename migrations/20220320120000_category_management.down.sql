@@ -0,0 +1,3 @@
+ALTER TABLE category DROP COLUMN IF EXISTS hidden;
+
+ALTER TABLE category DROP COLUMN IF EXISTS position;
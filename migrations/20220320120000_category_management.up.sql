@@ -0,0 +1,7 @@
+ALTER TABLE category ADD COLUMN position INT NOT NULL DEFAULT 0;
+
+ALTER TABLE category ADD COLUMN hidden BOOLEAN NOT NULL DEFAULT FALSE;
+
+UPDATE category AS c SET position = o.position
+FROM (SELECT id, row_number() OVER (ORDER BY name_ua) - 1 AS position FROM category) AS o
+WHERE c.id = o.id;
@@ -0,0 +1,3 @@
+ALTER TABLE category ADD COLUMN parent_id UUID REFERENCES category (id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS category_parent_id_idx ON category (parent_id);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS subscription_locality_id_idx;
+
+DROP INDEX IF EXISTS locality_parent_id_idx;
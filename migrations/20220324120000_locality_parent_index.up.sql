@@ -0,0 +1,3 @@
+CREATE INDEX IF NOT EXISTS locality_parent_id_idx ON locality (parent_id);
+
+CREATE INDEX IF NOT EXISTS subscription_locality_id_idx ON subscription (locality_id);
@@ -0,0 +1,18 @@
+DROP INDEX IF EXISTS subscription_category_ids_idx;
+
+DROP INDEX IF EXISTS subscription_creator_locality_categories_idx;
+
+ALTER TABLE subscription ADD COLUMN category_id UUID REFERENCES category (id);
+
+-- multi-category subscriptions are split into one subscription per category
+INSERT INTO subscription (id, creator_id, category_ids, category_id, locality_id, created_at)
+SELECT gen_random_uuid(), s.creator_id, s.category_ids, c.id, s.locality_id, s.created_at
+FROM subscription AS s, unnest(s.category_ids[2:]) AS c(id);
+
+UPDATE subscription SET category_id = category_ids[1] WHERE category_id IS NULL;
+
+ALTER TABLE subscription ALTER COLUMN category_id SET NOT NULL;
+
+ALTER TABLE subscription DROP COLUMN category_ids;
+
+ALTER TABLE subscription ADD UNIQUE (creator_id, category_id, locality_id);
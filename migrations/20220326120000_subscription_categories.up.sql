@@ -0,0 +1,14 @@
+ALTER TABLE subscription ADD COLUMN category_ids UUID[];
+
+UPDATE subscription SET category_ids = ARRAY [category_id];
+
+ALTER TABLE subscription ALTER COLUMN category_ids SET NOT NULL;
+
+-- drops unique constraint on (creator_id, category_id, locality_id) as well
+ALTER TABLE subscription DROP COLUMN category_id;
+
+-- category_ids are stored sorted, so equal sets are equal arrays
+CREATE UNIQUE INDEX IF NOT EXISTS subscription_creator_locality_categories_idx
+    ON subscription (creator_id, locality_id, category_ids);
+
+CREATE INDEX IF NOT EXISTS subscription_category_ids_idx ON subscription USING gin (category_ids);
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS notification_queue;
+
+DROP TABLE IF EXISTS user_preference;
+
+DROP TYPE IF EXISTS notification_mode;
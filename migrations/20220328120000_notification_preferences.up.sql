@@ -0,0 +1,22 @@
+CREATE TYPE notification_mode AS ENUM ('INSTANT', 'HOURLY', 'DAILY');
+
+CREATE TABLE IF NOT EXISTS user_preference
+(
+    user_id           UUID PRIMARY KEY REFERENCES app_user (id) ON DELETE CASCADE,
+    notification_mode notification_mode NOT NULL DEFAULT 'INSTANT',
+    -- hours in Kyiv time, notifications are queued from quiet_hours_from till quiet_hours_to
+    quiet_hours_from  SMALLINT CHECK (quiet_hours_from BETWEEN 0 AND 23),
+    quiet_hours_to    SMALLINT CHECK (quiet_hours_to BETWEEN 0 AND 23),
+    last_digest_at    TIMESTAMP,
+    updated_at        TIMESTAMP         NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS notification_queue
+(
+    id              UUID PRIMARY KEY,
+    user_id         UUID      NOT NULL REFERENCES app_user (id) ON DELETE CASCADE,
+    subscription_id UUID      NOT NULL REFERENCES subscription (id) ON DELETE CASCADE,
+    help_id         UUID      NOT NULL REFERENCES help (id) ON DELETE CASCADE,
+    created_at      TIMESTAMP NOT NULL,
+    UNIQUE (user_id, help_id)
+);
@@ -0,0 +1,4 @@
+ALTER TABLE user_preference
+    DROP COLUMN IF EXISTS search_radius,
+    DROP COLUMN IF EXISTS default_locality_id,
+    DROP COLUMN IF EXISTS share_contact;
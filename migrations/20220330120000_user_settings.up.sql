@@ -0,0 +1,5 @@
+ALTER TABLE user_preference
+    -- km around the chosen locality to search helps in, 0 searches the locality and its neighbourhood only
+    ADD COLUMN search_radius       SMALLINT NOT NULL DEFAULT 0 CHECK (search_radius >= 0),
+    ADD COLUMN default_locality_id INT REFERENCES locality (id) ON DELETE SET NULL,
+    ADD COLUMN share_contact       BOOLEAN  NOT NULL DEFAULT FALSE;
@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS user_locality
+(
+    user_id     UUID      NOT NULL REFERENCES app_user (id) ON DELETE CASCADE,
+    locality_id INT       NOT NULL REFERENCES locality (id) ON DELETE CASCADE,
+    used_at     TIMESTAMP NOT NULL,
+    PRIMARY KEY (user_id, locality_id)
+);
+
+CREATE INDEX IF NOT EXISTS user_locality_used_at_idx ON user_locality (user_id, used_at DESC);
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS help_channel_post;
@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS help_channel_post
+(
+    help_id    UUID      NOT NULL REFERENCES help (id) ON DELETE CASCADE,
+    chat_id    BIGINT    NOT NULL,
+    message_id INT       NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    PRIMARY KEY (help_id, chat_id)
+);
@@ -0,0 +1,10 @@
+DELETE FROM subscription WHERE chat_id IS NOT NULL;
+
+DROP INDEX IF EXISTS subscription_chat_locality_categories_idx;
+
+DROP INDEX IF EXISTS subscription_creator_locality_categories_idx;
+
+CREATE UNIQUE INDEX IF NOT EXISTS subscription_creator_locality_categories_idx
+    ON subscription (creator_id, locality_id, category_ids);
+
+ALTER TABLE subscription DROP COLUMN chat_id;
@@ -0,0 +1,13 @@
+-- group subscriptions post matching helps into the group chat instead of the creator private chat
+ALTER TABLE subscription ADD COLUMN chat_id BIGINT;
+
+DROP INDEX IF EXISTS subscription_creator_locality_categories_idx;
+
+CREATE UNIQUE INDEX IF NOT EXISTS subscription_creator_locality_categories_idx
+    ON subscription (creator_id, locality_id, category_ids) WHERE chat_id IS NULL;
+
+CREATE UNIQUE INDEX IF NOT EXISTS subscription_chat_locality_categories_idx
+    ON subscription (chat_id, locality_id, category_ids) WHERE chat_id IS NOT NULL;
+
+-- users who first wrote to the bot from a group got the group chat stored
+UPDATE app_user SET chat_id = tg_id;
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS help_search_vector_idx;
+
+ALTER TABLE help DROP COLUMN search_vector;
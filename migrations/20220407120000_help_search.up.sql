@@ -0,0 +1,5 @@
+-- russian stems and plain lowercase words, ukrainian words are matched by prefix of their stems
+ALTER TABLE help ADD COLUMN search_vector TSVECTOR
+    GENERATED ALWAYS AS (to_tsvector('russian', description) || to_tsvector('simple', description)) STORED;
+
+CREATE INDEX IF NOT EXISTS help_search_vector_idx ON help USING gin (search_vector);
@@ -0,0 +1,15 @@
+DELETE FROM subscription WHERE keywords IS NOT NULL;
+
+DROP INDEX IF EXISTS subscription_creator_locality_categories_idx;
+
+DROP INDEX IF EXISTS subscription_chat_locality_categories_idx;
+
+CREATE UNIQUE INDEX IF NOT EXISTS subscription_creator_locality_categories_idx
+    ON subscription (creator_id, locality_id, category_ids) WHERE chat_id IS NULL;
+
+CREATE UNIQUE INDEX IF NOT EXISTS subscription_chat_locality_categories_idx
+    ON subscription (chat_id, locality_id, category_ids) WHERE chat_id IS NOT NULL;
+
+ALTER TABLE subscription DROP COLUMN keywords_query;
+
+ALTER TABLE subscription DROP COLUMN keywords;
@@ -0,0 +1,14 @@
+-- keyword subscriptions match helps by description, their categories may be empty
+ALTER TABLE subscription ADD COLUMN keywords TEXT;
+
+ALTER TABLE subscription ADD COLUMN keywords_query TEXT;
+
+DROP INDEX IF EXISTS subscription_creator_locality_categories_idx;
+
+DROP INDEX IF EXISTS subscription_chat_locality_categories_idx;
+
+CREATE UNIQUE INDEX IF NOT EXISTS subscription_creator_locality_categories_idx
+    ON subscription (creator_id, locality_id, category_ids, coalesce(keywords, '')) WHERE chat_id IS NULL;
+
+CREATE UNIQUE INDEX IF NOT EXISTS subscription_chat_locality_categories_idx
+    ON subscription (chat_id, locality_id, category_ids, coalesce(keywords, '')) WHERE chat_id IS NOT NULL;
@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS help_attachment
+(
+    help_id    UUID      NOT NULL REFERENCES help (id) ON DELETE CASCADE,
+    position   INT       NOT NULL,
+    type       TEXT      NOT NULL,
+    file_id    TEXT      NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    PRIMARY KEY (help_id, position)
+);
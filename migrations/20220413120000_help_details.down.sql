@@ -0,0 +1,9 @@
+ALTER TABLE help
+    DROP COLUMN IF EXISTS capacity,
+    DROP COLUMN IF EXISTS capacity_unit,
+    DROP COLUMN IF EXISTS available_from,
+    DROP COLUMN IF EXISTS available_to,
+    DROP COLUMN IF EXISTS weekdays,
+    DROP COLUMN IF EXISTS hours_from,
+    DROP COLUMN IF EXISTS hours_to,
+    DROP COLUMN IF EXISTS languages;
@@ -0,0 +1,10 @@
+-- weekdays are numbered from 0 for Sunday, hours are in Kyiv time and the window may pass midnight
+ALTER TABLE help
+    ADD COLUMN IF NOT EXISTS capacity       INT,
+    ADD COLUMN IF NOT EXISTS capacity_unit  TEXT,
+    ADD COLUMN IF NOT EXISTS available_from DATE,
+    ADD COLUMN IF NOT EXISTS available_to   DATE,
+    ADD COLUMN IF NOT EXISTS weekdays       INT[],
+    ADD COLUMN IF NOT EXISTS hours_from     INT,
+    ADD COLUMN IF NOT EXISTS hours_to       INT,
+    ADD COLUMN IF NOT EXISTS languages      TEXT[];
@@ -0,0 +1,3 @@
+ALTER TABLE help
+    DROP COLUMN IF EXISTS contact_phone,
+    DROP COLUMN IF EXISTS contact_username;
@@ -0,0 +1,4 @@
+-- phone is E.164, username is a telegram username without @
+ALTER TABLE help
+    ADD COLUMN IF NOT EXISTS contact_phone    TEXT,
+    ADD COLUMN IF NOT EXISTS contact_username TEXT;
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS help_description_normalized_idx;
+
+ALTER TABLE help
+    DROP COLUMN IF EXISTS description_normalized,
+    DROP COLUMN IF EXISTS phones,
+    DROP COLUMN IF EXISTS duplicate_of;
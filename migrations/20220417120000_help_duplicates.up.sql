@@ -0,0 +1,13 @@
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+-- near-duplicates are found by trigram similarity of lowercase letters and digits of the description
+-- and by phone numbers of the contact and the description, normalized to E.164
+ALTER TABLE help
+    ADD COLUMN IF NOT EXISTS description_normalized TEXT
+        GENERATED ALWAYS AS (trim(regexp_replace(lower(description), '[^[:alnum:]]+', ' ', 'g'))) STORED,
+    ADD COLUMN IF NOT EXISTS phones                 TEXT[],
+    ADD COLUMN IF NOT EXISTS duplicate_of           UUID REFERENCES help (id);
+
+CREATE INDEX IF NOT EXISTS help_description_normalized_idx ON help USING gin (description_normalized gin_trgm_ops);
+
+UPDATE help SET phones = ARRAY [contact_phone] WHERE contact_phone IS NOT NULL;
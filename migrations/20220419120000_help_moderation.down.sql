@@ -0,0 +1,10 @@
+DROP TABLE IF EXISTS banned_phrase;
+
+DROP INDEX IF EXISTS help_pending_idx;
+
+ALTER TABLE help
+    DROP COLUMN IF EXISTS status,
+    DROP COLUMN IF EXISTS spam_score,
+    DROP COLUMN IF EXISTS spam_reasons;
+
+DROP TYPE IF EXISTS help_status;
@@ -0,0 +1,17 @@
+CREATE TYPE help_status AS ENUM ('ACTIVE', 'PENDING', 'REJECTED');
+
+-- suspicious helps are held as PENDING until approved by a moderator,
+-- spam_reasons explain the score given by content checks
+ALTER TABLE help
+    ADD COLUMN IF NOT EXISTS status       help_status NOT NULL DEFAULT 'ACTIVE',
+    ADD COLUMN IF NOT EXISTS spam_score   INT         NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS spam_reasons TEXT[];
+
+CREATE INDEX IF NOT EXISTS help_pending_idx ON help (created_at) WHERE status = 'PENDING';
+
+-- lowercase phrases, a help containing any of them is suspicious
+CREATE TABLE IF NOT EXISTS banned_phrase
+(
+    phrase     TEXT PRIMARY KEY,
+    created_at TIMESTAMP NOT NULL
+);
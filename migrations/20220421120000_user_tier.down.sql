@@ -0,0 +1,3 @@
+ALTER TABLE app_user DROP COLUMN IF EXISTS tier;
+
+DROP TYPE IF EXISTS user_tier;
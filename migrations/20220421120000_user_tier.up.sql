@@ -0,0 +1,4 @@
+-- tiers override per-user limits configured for the service
+CREATE TYPE user_tier AS ENUM ('NEW', 'TRUSTED', 'ORGANISATION', 'ADMIN');
+
+ALTER TABLE app_user ADD COLUMN IF NOT EXISTS tier user_tier NOT NULL DEFAULT 'NEW';
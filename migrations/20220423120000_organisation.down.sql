@@ -0,0 +1,5 @@
+ALTER TABLE help DROP COLUMN IF EXISTS organisation_id;
+
+DROP TABLE IF EXISTS organisation_member;
+
+DROP TABLE IF EXISTS organisation;
@@ -0,0 +1,20 @@
+CREATE TABLE IF NOT EXISTS organisation
+(
+    id          UUID PRIMARY KEY,
+    name        TEXT      NOT NULL UNIQUE,
+    description TEXT      NOT NULL,
+    contact     TEXT      NOT NULL,
+    verified    BOOLEAN   NOT NULL DEFAULT FALSE,
+    created_at  TIMESTAMP NOT NULL
+);
+
+-- members may post helps on behalf of the organisation
+CREATE TABLE IF NOT EXISTS organisation_member
+(
+    organisation_id UUID      NOT NULL REFERENCES organisation (id) ON DELETE CASCADE,
+    user_id         UUID      NOT NULL REFERENCES app_user (id) ON DELETE CASCADE,
+    created_at      TIMESTAMP NOT NULL,
+    PRIMARY KEY (organisation_id, user_id)
+);
+
+ALTER TABLE help ADD COLUMN IF NOT EXISTS organisation_id UUID REFERENCES organisation (id) ON DELETE SET NULL;
@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS user_reputation;
+
+DROP INDEX IF EXISTS help_feedback_unasked_idx;
+
+DROP TABLE IF EXISTS help_feedback;
+
+DROP TYPE IF EXISTS feedback_answer;
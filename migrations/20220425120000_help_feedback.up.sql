@@ -0,0 +1,24 @@
+CREATE TYPE feedback_answer AS ENUM ('YES', 'NO', 'REPORT');
+
+-- seekers who opened the contact of a help are asked later whether they received help
+CREATE TABLE IF NOT EXISTS help_feedback
+(
+    help_id      UUID      NOT NULL REFERENCES help (id) ON DELETE CASCADE,
+    user_id      UUID      NOT NULL REFERENCES app_user (id) ON DELETE CASCADE,
+    contacted_at TIMESTAMP NOT NULL,
+    asked_at     TIMESTAMP,
+    answer       feedback_answer,
+    answered_at  TIMESTAMP,
+    PRIMARY KEY (help_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS help_feedback_unasked_idx ON help_feedback (contacted_at) WHERE asked_at IS NULL;
+
+-- answers aggregated per volunteer
+CREATE TABLE IF NOT EXISTS user_reputation
+(
+    user_id  UUID PRIMARY KEY REFERENCES app_user (id) ON DELETE CASCADE,
+    positive INT NOT NULL DEFAULT 0,
+    negative INT NOT NULL DEFAULT 0,
+    reports  INT NOT NULL DEFAULT 0
+);
@@ -0,0 +1,2 @@
+-- recounted votes are kept, answers counted per help can't be told apart anymore
+DROP INDEX IF EXISTS help_creator_id_idx;
@@ -0,0 +1,16 @@
+CREATE INDEX IF NOT EXISTS help_creator_id_idx ON help (creator_id);
+
+-- every seeker votes once per volunteer with their latest answer, recount the votes
+DELETE FROM user_reputation;
+
+INSERT INTO user_reputation (user_id, positive, negative, reports)
+SELECT v.creator_id,
+       count(*) FILTER (WHERE v.answer = 'YES'),
+       count(*) FILTER (WHERE v.answer != 'YES'),
+       count(*) FILTER (WHERE v.answer = 'REPORT')
+FROM (SELECT DISTINCT ON (h.creator_id, f.user_id) h.creator_id, f.answer
+      FROM help_feedback f
+               JOIN help h ON h.id = f.help_id
+      WHERE f.answer IS NOT NULL
+      ORDER BY h.creator_id, f.user_id, f.answered_at DESC) v
+GROUP BY v.creator_id;